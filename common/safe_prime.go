@@ -27,6 +27,19 @@ type (
 	}
 )
 
+// NewGermainSafePrime validates a safe prime `p` obtained elsewhere, e.g. from an HSM, and returns it together with its
+// Sophie Germain prime `q = (p-1)/2`
+func NewGermainSafePrime(p *big.Int) (*GermainSafePrime, error) {
+	if p == nil || p.Sign() <= 0 || p.Bit(0) == 0 {
+		return nil, errors.New("a safe prime must be a positive odd number")
+	}
+	sgp := &GermainSafePrime{q: new(big.Int).Rsh(p, 1), p: new(big.Int).Set(p)}
+	if !sgp.Validate() {
+		return nil, errors.New("not a safe prime")
+	}
+	return sgp, nil
+}
+
 func (sgp *GermainSafePrime) Prime() *big.Int {
 	return sgp.q
 }
@@ -125,6 +138,12 @@ var ErrGeneratorCancelled = fmt.Errorf("generator work cancelled")
 // generated safe prime, the two most significant bits are always set to `1`
 // - we don't want the generated number to be too small.
 func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader) ([]*GermainSafePrime, error) {
+	return GetRandomSafePrimesConcurrentWithProgress(ctx, bitLen, numPrimes, concurrency, rand, new(uint64))
+}
+
+// GetRandomSafePrimesConcurrentWithProgress works like GetRandomSafePrimesConcurrent and also adds the number of
+// candidates that reached the final primality test to `tested`, which is updated atomically and may be read concurrently.
+func GetRandomSafePrimesConcurrentWithProgress(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader, tested *uint64) ([]*GermainSafePrime, error) {
 	if bitLen < 6 {
 		return nil, errors.New("safe prime size must be at least 6 bits")
 	}
@@ -148,7 +167,7 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 	for i := 0; i < concurrency; i++ {
 		waitGroup.Add(1)
 		runGenPrimeRoutine(
-			generatorCtx, primeCh, errCh, waitGroup, rand, bitLen,
+			generatorCtx, primeCh, errCh, waitGroup, rand, bitLen, tested,
 		)
 	}
 
@@ -210,6 +229,7 @@ func runGenPrimeRoutine(
 	waitGroup *sync.WaitGroup,
 	rand io.Reader,
 	pBitLen int,
+	tested *uint64,
 ) {
 	qBitLen := pBitLen - 1
 	b := uint(qBitLen % 8)
@@ -312,6 +332,7 @@ func runGenPrimeRoutine(
 				// There is a tiny possibility that, by adding delta, we caused
 				// the number to be one bit too long. Thus we check BitLen
 				// here.
+				atomic.AddUint64(tested, 1)
 				if q.ProbablyPrime(20) &&
 					isPocklingtonCriterionSatisfied(p) &&
 					q.BitLen() == qBitLen {
@@ -43,6 +43,26 @@ func Test_Validate_Bad(t *testing.T) {
 	assert.False(t, sgp.Validate())
 }
 
+func TestNewGermainSafePrime(t *testing.T) {
+	sgp, err := NewGermainSafePrime(big.NewInt(23))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(11), sgp.Prime())
+	assert.Equal(t, big.NewInt(23), sgp.SafePrime())
+
+	_, err = NewGermainSafePrime(big.NewInt(13)) // 13 = 2*6+1
+	assert.Error(t, err)
+	_, err = NewGermainSafePrime(big.NewInt(22))
+	assert.Error(t, err)
+}
+
+func TestGetRandomSafePrimesConcurrentWithProgress(t *testing.T) {
+	var tested uint64
+	sgps, err := GetRandomSafePrimesConcurrentWithProgress(context.Background(), 256, 1, 1, rand.Reader, &tested)
+	assert.NoError(t, err)
+	assert.True(t, sgps[0].Validate())
+	assert.NotZero(t, tested)
+}
+
 func TestGetRandomGermainPrimeConcurrent(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
 	defer cancel()
@@ -0,0 +1,261 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// secp256k1 and Ed25519 scalars are 32 bytes
+	scalarLen = 32
+
+	bitcoinCompactHeader   = 27
+	bitcoinCompressedFlag  = 4
+	ethereumRecoveryOffset = 27
+	eip155RecoveryOffset   = 35
+)
+
+var (
+	// the order of the Ed25519 base point, 2^252 + 27742317777372353535851937790883648493
+	ed25519Order, _ = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+)
+
+type derSignature struct {
+	R, S *big.Int
+}
+
+// DERSignature encodes an ECDSA signature as the ASN.1 DER sequence of R and S, as used by bitcoin scripts, X.509 and
+// crypto/ecdsa.VerifyASN1.
+func (sig *SignatureData) DERSignature() ([]byte, error) {
+	r, s, err := sig.rs()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(derSignature{R: r, S: s})
+}
+
+// BitcoinCompactSignature encodes a secp256k1 signature in the 65-byte compact form of bitcoin message signing: a header
+// byte of 27 + recovery ID, plus 4 if the public key is serialised compressed, followed by R and S.
+func (sig *SignatureData) BitcoinCompactSignature(compressed bool) ([]byte, error) {
+	recid, err := sig.recoveryID(3)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := sig.fixedRS()
+	if err != nil {
+		return nil, err
+	}
+	header := byte(bitcoinCompactHeader) + recid
+	if compressed {
+		header += bitcoinCompressedFlag
+	}
+	return append([]byte{header}, rs...), nil
+}
+
+// EthereumSignature encodes a secp256k1 signature as the 65 bytes R || S || V with V = 27 + recovery ID, as returned by
+// eth_sign. Recovery IDs 2 and 3, for which R.x overflowed the order, cannot be expressed by ethereum and are refused.
+func (sig *SignatureData) EthereumSignature() ([]byte, error) {
+	recid, err := sig.recoveryID(1)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := sig.fixedRS()
+	if err != nil {
+		return nil, err
+	}
+	return append(rs, byte(ethereumRecoveryOffset)+recid), nil
+}
+
+// EIP155V returns the V of a transaction signature replay-protected by EIP-155, recovery ID + 2 * chainID + 35.
+// R and S of the transaction are those of the signature.
+func (sig *SignatureData) EIP155V(chainID *big.Int) (*big.Int, error) {
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, errors.New("EIP155V: the chain ID must be positive")
+	}
+	recid, err := sig.recoveryID(1)
+	if err != nil {
+		return nil, err
+	}
+	v := new(big.Int).Lsh(chainID, 1)
+	return v.Add(v, big.NewInt(int64(eip155RecoveryOffset)+int64(recid))), nil
+}
+
+// Ed25519Signature returns the 64-byte signature of RFC 8032: the encoding of the point R followed by S, both little
+// endian. It is rebuilt from R and S, as they are output by EdDSA signing.
+func (sig *SignatureData) Ed25519Signature() ([]byte, error) {
+	if sig == nil || len(sig.R) == 0 || len(sig.R) > scalarLen || len(sig.S) == 0 || len(sig.S) > scalarLen {
+		return nil, errors.New("Ed25519Signature: R and S must be set and at most 32 bytes")
+	}
+	if new(big.Int).SetBytes(sig.S).Cmp(ed25519Order) >= 0 {
+		return nil, errors.New("Ed25519Signature: S is not reduced")
+	}
+	out := make([]byte, 2*scalarLen)
+	copy(out[scalarLen-len(sig.R):scalarLen], sig.R)
+	copy(out[2*scalarLen-len(sig.S):], sig.S)
+	reverseBytes(out[:scalarLen])
+	reverseBytes(out[scalarLen:])
+	return out, nil
+}
+
+// ----- //
+
+// ParseDERSignature parses a strict DER ECDSA signature on the curve `ec`. R and S are padded to the byte length of the
+// curve order, as signing outputs them; the recovery ID is unknown and left empty.
+func ParseDERSignature(ec elliptic.Curve, der []byte) (*SignatureData, error) {
+	parsed := new(derSignature)
+	rest, err := asn1.Unmarshal(der, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("ParseDERSignature: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("ParseDERSignature: trailing bytes after the signature")
+	}
+	// asn1 accepts some encodings that are not DER, such as long-form lengths, so the encoding must round-trip
+	if canonical, err := asn1.Marshal(*parsed); err != nil || !bytes.Equal(canonical, der) {
+		return nil, errors.New("ParseDERSignature: the signature is not strictly DER encoded")
+	}
+	return newECDSASignatureData(ec, parsed.R, parsed.S, nil)
+}
+
+// ParseBitcoinCompactSignature parses a 65-byte bitcoin compact secp256k1 signature, returning whether the public key
+// it recovers to is serialised compressed.
+func ParseBitcoinCompactSignature(ec elliptic.Curve, compact []byte) (*SignatureData, bool, error) {
+	if len(compact) != 1+2*scalarLen {
+		return nil, false, fmt.Errorf("ParseBitcoinCompactSignature: expected %d bytes, got %d", 1+2*scalarLen, len(compact))
+	}
+	header := compact[0]
+	if header < bitcoinCompactHeader || header >= bitcoinCompactHeader+2*bitcoinCompressedFlag {
+		return nil, false, fmt.Errorf("ParseBitcoinCompactSignature: invalid header byte %d", header)
+	}
+	recid := header - bitcoinCompactHeader
+	compressed := recid&bitcoinCompressedFlag != 0
+	recid &^= bitcoinCompressedFlag
+	r, s := new(big.Int).SetBytes(compact[1:1+scalarLen]), new(big.Int).SetBytes(compact[1+scalarLen:])
+	sig, err := newECDSASignatureData(ec, r, s, []byte{recid})
+	if err != nil {
+		return nil, false, err
+	}
+	return sig, compressed, nil
+}
+
+// ParseEthereumSignature parses the 65 bytes R || S || V of a secp256k1 signature, with V either 27/28 or the bare
+// recovery ID 0/1. For an EIP-155 V, see RecoveryIDFromEIP155V.
+func ParseEthereumSignature(ec elliptic.Curve, rsv []byte) (*SignatureData, error) {
+	if len(rsv) != 2*scalarLen+1 {
+		return nil, fmt.Errorf("ParseEthereumSignature: expected %d bytes, got %d", 2*scalarLen+1, len(rsv))
+	}
+	recid := rsv[2*scalarLen]
+	if recid >= ethereumRecoveryOffset {
+		recid -= ethereumRecoveryOffset
+	}
+	if recid > 1 {
+		return nil, fmt.Errorf("ParseEthereumSignature: invalid V %d", rsv[2*scalarLen])
+	}
+	r, s := new(big.Int).SetBytes(rsv[:scalarLen]), new(big.Int).SetBytes(rsv[scalarLen:2*scalarLen])
+	return newECDSASignatureData(ec, r, s, []byte{recid})
+}
+
+// RecoveryIDFromEIP155V returns the recovery ID encoded in the V of an EIP-155 transaction signature for `chainID`
+func RecoveryIDFromEIP155V(v, chainID *big.Int) (byte, error) {
+	if v == nil || chainID == nil || chainID.Sign() <= 0 {
+		return 0, errors.New("RecoveryIDFromEIP155V: V and a positive chain ID are required")
+	}
+	recid := new(big.Int).Sub(v, new(big.Int).Lsh(chainID, 1))
+	recid.Sub(recid, big.NewInt(eip155RecoveryOffset))
+	if recid.Sign() < 0 || recid.Cmp(big.NewInt(1)) > 0 {
+		return 0, fmt.Errorf("RecoveryIDFromEIP155V: V %s is not for chain ID %s", v, chainID)
+	}
+	return byte(recid.Uint64()), nil
+}
+
+// ParseEd25519Signature parses a 64-byte RFC 8032 signature. S must be reduced modulo the group order, as RFC 8032
+// requires of verifiers, so that a signature has a single encoding.
+func ParseEd25519Signature(bz []byte) (*SignatureData, error) {
+	if len(bz) != 2*scalarLen {
+		return nil, fmt.Errorf("ParseEd25519Signature: expected %d bytes, got %d", 2*scalarLen, len(bz))
+	}
+	r := append([]byte{}, bz[:scalarLen]...)
+	s := append([]byte{}, bz[scalarLen:]...)
+	reverseBytes(r)
+	reverseBytes(s)
+	sInt := new(big.Int).SetBytes(s)
+	if sInt.Cmp(ed25519Order) >= 0 {
+		return nil, errors.New("ParseEd25519Signature: S is not reduced")
+	}
+	return &SignatureData{
+		Signature: append([]byte{}, bz...),
+		R:         new(big.Int).SetBytes(r).Bytes(),
+		S:         sInt.Bytes(),
+	}, nil
+}
+
+// ----- //
+
+func newECDSASignatureData(ec elliptic.Curve, r, s *big.Int, recovery []byte) (*SignatureData, error) {
+	N := ec.Params().N
+	if r.Sign() <= 0 || r.Cmp(N) >= 0 || s.Sign() <= 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("r or s is out of range")
+	}
+	orderLen := (N.BitLen() + 7) / 8
+	rBz, sBz := make([]byte, orderLen), make([]byte, orderLen)
+	r.FillBytes(rBz)
+	s.FillBytes(sBz)
+	return &SignatureData{
+		Signature:         append(append([]byte{}, rBz...), sBz...),
+		SignatureRecovery: recovery,
+		R:                 rBz,
+		S:                 sBz,
+	}, nil
+}
+
+func (sig *SignatureData) rs() (*big.Int, *big.Int, error) {
+	if sig == nil || len(sig.R) == 0 || len(sig.S) == 0 {
+		return nil, nil, errors.New("the signature has no R or S")
+	}
+	r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+	if r.Sign() == 0 || s.Sign() == 0 {
+		return nil, nil, errors.New("R or S of the signature is zero")
+	}
+	return r, s, nil
+}
+
+// fixedRS returns R || S, each padded to 32 bytes
+func (sig *SignatureData) fixedRS() ([]byte, error) {
+	r, s, err := sig.rs()
+	if err != nil {
+		return nil, err
+	}
+	if r.BitLen() > 8*scalarLen || s.BitLen() > 8*scalarLen {
+		return nil, errors.New("R or S of the signature is longer than 32 bytes")
+	}
+	rs := make([]byte, 2*scalarLen)
+	r.FillBytes(rs[:scalarLen])
+	s.FillBytes(rs[scalarLen:])
+	return rs, nil
+}
+
+func (sig *SignatureData) recoveryID(max byte) (byte, error) {
+	if sig == nil || len(sig.SignatureRecovery) != 1 {
+		return 0, errors.New("the signature has no recovery ID")
+	}
+	if recid := sig.SignatureRecovery[0]; recid <= max {
+		return recid, nil
+	}
+	return 0, fmt.Errorf("the recovery ID %d cannot be encoded, at most %d is supported", sig.SignatureRecovery[0], max)
+}
+
+func reverseBytes(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestDERSignature(t *testing.T) {
+	for _, ec := range []elliptic.Curve{btcec.S256(), elliptic.P256(), elliptic.P521()} {
+		key, err := ecdsa.GenerateKey(ec, rand.Reader)
+		assert.NoError(t, err)
+		digest := common.SHA512_256([]byte("der"))
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		assert.NoError(t, err)
+
+		sig := &common.SignatureData{R: r.Bytes(), S: s.Bytes()}
+		der, err := sig.DERSignature()
+		assert.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest, der), "the DER signature must verify")
+
+		parsed, err := common.ParseDERSignature(ec, der)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, new(big.Int).SetBytes(parsed.R).Cmp(r))
+			assert.Equal(t, 0, new(big.Int).SetBytes(parsed.S).Cmp(s))
+			assert.Equal(t, (ec.Params().N.BitLen()+7)/8, len(parsed.R))
+		}
+	}
+
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	digest := common.SHA512_256([]byte("btcec"))
+	der := btcecdsa.Sign(priv, digest).Serialize()
+	parsed, err := common.ParseDERSignature(btcec.S256(), der)
+	if assert.NoError(t, err) {
+		reencoded, err := parsed.DERSignature()
+		assert.NoError(t, err)
+		assert.Equal(t, der, reencoded)
+	}
+
+	// trailing bytes, a long-form length and an out of range S
+	_, err = common.ParseDERSignature(btcec.S256(), append(der, 0))
+	assert.Error(t, err)
+	longForm := append([]byte{der[0], 0x81}, der[1:]...)
+	_, err = common.ParseDERSignature(btcec.S256(), longForm)
+	assert.Error(t, err)
+	outOfRange, err := (&common.SignatureData{R: parsed.R, S: btcec.S256().Params().N.Bytes()}).DERSignature()
+	assert.NoError(t, err)
+	_, err = common.ParseDERSignature(btcec.S256(), outOfRange)
+	assert.Error(t, err)
+}
+
+func TestBitcoinCompactAndEthereumSignature(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	for i := 0; i < 8; i++ {
+		digest := common.SHA512_256([]byte{byte(i)})
+		for _, compressed := range []bool{false, true} {
+			compact, err := btcecdsa.SignCompact(priv, digest, compressed)
+			assert.NoError(t, err)
+
+			sig, isCompressed, err := common.ParseBitcoinCompactSignature(btcec.S256(), compact)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, compressed, isCompressed)
+			reencoded, err := sig.BitcoinCompactSignature(compressed)
+			assert.NoError(t, err)
+			assert.Equal(t, compact, reencoded)
+
+			// ethereum carries the same recovery ID at the end, without the compression flag
+			rsv, err := sig.EthereumSignature()
+			if assert.NoError(t, err) {
+				assert.Equal(t, compact[1:], rsv[:64])
+				assert.Equal(t, 27+sig.SignatureRecovery[0], rsv[64])
+				ethSig, err := common.ParseEthereumSignature(btcec.S256(), rsv)
+				if assert.NoError(t, err) {
+					assert.Equal(t, sig.Signature, ethSig.Signature)
+					assert.Equal(t, sig.SignatureRecovery, ethSig.SignatureRecovery)
+				}
+				rsv[64] -= 27
+				_, err = common.ParseEthereumSignature(btcec.S256(), rsv)
+				assert.NoError(t, err)
+			}
+			pub, _, err := btcecdsa.RecoverCompact(reencoded, digest)
+			assert.NoError(t, err)
+			assert.True(t, pub.IsEqual(priv.PubKey()))
+		}
+	}
+
+	_, _, err = common.ParseBitcoinCompactSignature(btcec.S256(), make([]byte, 65))
+	assert.Error(t, err, "the header byte is invalid")
+	overflowed := &common.SignatureData{R: []byte{1}, S: []byte{1}, SignatureRecovery: []byte{2}}
+	_, err = overflowed.EthereumSignature()
+	assert.Error(t, err, "ethereum cannot express recovery ID 2")
+	_, err = overflowed.BitcoinCompactSignature(true)
+	assert.NoError(t, err)
+}
+
+func TestEIP155V(t *testing.T) {
+	sig := &common.SignatureData{R: []byte{1}, S: []byte{1}, SignatureRecovery: []byte{1}}
+	v, err := sig.EIP155V(big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(38), v.Int64())
+
+	// a chain ID that does not fit in a byte
+	chainID := big.NewInt(1337802)
+	v, err = sig.EIP155V(chainID)
+	assert.NoError(t, err)
+	recid, err := common.RecoveryIDFromEIP155V(v, chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), recid)
+
+	_, err = common.RecoveryIDFromEIP155V(v, big.NewInt(1))
+	assert.Error(t, err, "V is for another chain")
+	_, err = sig.EIP155V(big.NewInt(0))
+	assert.Error(t, err)
+}
+
+func TestEd25519Signature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	for i := 0; i < 8; i++ {
+		msg := []byte{0, byte(i)}
+		bz := ed25519.Sign(priv, msg)
+		sig, err := common.ParseEd25519Signature(bz)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, bz, sig.Signature)
+
+		// rebuilt from R and S alone, as EdDSA signing outputs them
+		rebuilt, err := (&common.SignatureData{R: sig.R, S: sig.S}).Ed25519Signature()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, rebuilt)
+		assert.True(t, ed25519.Verify(pub, msg, rebuilt))
+	}
+
+	// S is not reduced once the order is added to it
+	bz := ed25519.Sign(priv, []byte("malleable"))
+	s := new(big.Int).SetBytes(reversed(bz[32:]))
+	L, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	s.Add(s, L)
+	malleated := append(append([]byte{}, bz[:32]...), reversed(s.FillBytes(make([]byte, 32)))...)
+	_, err = common.ParseEd25519Signature(malleated)
+	assert.Error(t, err)
+	_, err = common.ParseEd25519Signature(bz[:63])
+	assert.Error(t, err)
+}
+
+func reversed(bz []byte) []byte {
+	out := bytes.Repeat([]byte{0}, len(bz))
+	for i := range bz {
+		out[len(bz)-1-i] = bz[i]
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Non-hardened derivation of Ed25519 keys in the style of BIP32-Ed25519 (Khovratovich and Law), see
+// https://input-output-hk.github.io/adrestia/static/Ed25519_BIP.pdf . The child of the public key A with the chain
+// code c at the index i is A + 8*ZL*G, where Z = HMAC-SHA512(c, 0x02 || A || i) and ZL are the first 28 bytes of Z
+// read little endian; the child chain code is the right half of HMAC-SHA512(c, 0x03 || A || i). A and i are encoded
+// little endian, as in RFC 8032. As with BIP-32 above, only non-hardened keys can be derived.
+
+const (
+	ed25519PubKeyLen = 32
+
+	ed25519TagZ         byte = 0x2
+	ed25519TagChainCode byte = 0x3
+
+	// ZL is truncated to 28 bytes, so that 8*ZL stays below 2^255 and the child scalar a multiple of the cofactor
+	ed25519ZLLen = 28
+)
+
+// DeriveEd25519ChildKeyFromHierarchy derives the Ed25519 key at the path `indicesHierarchy` under `pk`, whose public
+// key must be on the edwards curve. It returns the sum modulo the group order of the deltas of every step, which is to
+// be added to the secret key (or to each of its shares), and the child key.
+func DeriveEd25519ChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+	k := pk
+	modN := common.ModInt(edwards.Edwards().Params().N)
+	delta := big.NewInt(0)
+	for _, index := range indicesHierarchy {
+		stepDelta, childKey, err := DeriveEd25519ChildKey(index, k)
+		if err != nil {
+			return nil, nil, err
+		}
+		k = childKey
+		delta = modN.Add(delta, stepDelta)
+	}
+	return delta, k, nil
+}
+
+// DeriveEd25519ChildKey derives the non-hardened Ed25519 child key of `pk` at `index`. It returns the delta 8*ZL and the
+// child key; Version is carried over from the parent and ParentFP is the hash160 of the encoded parent key.
+func DeriveEd25519ChildKey(index uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, errors.New("the index must be non-hardened")
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	ec := edwards.Edwards()
+	parentPk, err := crypto.NewECPoint(ec, pk.X, pk.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+	parentBz := edwards.NewPublicKey(pk.X, pk.Y).Serialize()
+
+	z := ed25519HMAC(pk.ChainCode, ed25519TagZ, parentBz, index)
+	zl := append([]byte{}, z[:ed25519ZLLen]...)
+	reverseBytes(zl)
+	delta := new(big.Int).Lsh(new(big.Int).SetBytes(zl), 3)
+	delta.Mod(delta, ec.Params().N)
+	if delta.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+
+	childPk, err := parentPk.Add(crypto.ScalarBaseMult(ec, delta))
+	if err != nil {
+		return nil, nil, err
+	}
+	childChainCode := ed25519HMAC(pk.ChainCode, ed25519TagChainCode, parentBz, index)[32:]
+
+	return delta, &ExtendedKey{
+		PublicKey:  ecdsa.PublicKey{Curve: ec, X: childPk.X(), Y: childPk.Y()},
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  childChainCode,
+		ParentFP:   hash160(parentBz)[:4],
+		Version:    pk.Version,
+	}, nil
+}
+
+// ----- //
+
+func ed25519HMAC(chainCode []byte, tag byte, pubKey []byte, index uint32) []byte {
+	data := make([]byte, 1+ed25519PubKeyLen+4)
+	data[0] = tag
+	copy(data[1:], pubKey)
+	binary.LittleEndian.PutUint32(data[1+ed25519PubKeyLen:], index)
+	hmac512 := hmac.New(sha512.New, chainCode)
+	hmac512.Write(data)
+	return hmac512.Sum(nil)
+}
+
+func reverseBytes(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
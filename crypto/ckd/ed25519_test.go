@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+)
+
+func TestEd25519Derivation(t *testing.T) {
+	ec := edwards.Edwards()
+	N := ec.Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, N)
+	X := crypto.ScalarBaseMult(ec, x)
+	master := &ExtendedKey{
+		PublicKey: ecdsa.PublicKey{Curve: ec, X: X.X(), Y: X.Y()},
+		ChainCode: common.SHA512_256([]byte("chain code")),
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+
+	path := []uint32{44, 501, 0, 7}
+	delta, child, err := DeriveEd25519ChildKeyFromHierarchy(path, master)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint8(len(path)), child.Depth)
+	assert.Equal(t, path[len(path)-1], child.ChildIndex)
+
+	// the secret key of the child is the parent's plus the delta
+	childX := crypto.ScalarBaseMult(ec, new(big.Int).Mod(new(big.Int).Add(x, delta), N))
+	assert.Equal(t, 0, childX.X().Cmp(child.X))
+	assert.Equal(t, 0, childX.Y().Cmp(child.Y))
+
+	// deriving step by step gives the same key
+	k := master
+	for _, index := range path {
+		_, k, err = DeriveEd25519ChildKey(index, k)
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	assert.Equal(t, child.PublicKey, k.PublicKey)
+	assert.Equal(t, child.ChainCode, k.ChainCode)
+
+	_, sibling, err := DeriveEd25519ChildKey(1, master)
+	assert.NoError(t, err)
+	_, other, err := DeriveEd25519ChildKey(2, master)
+	assert.NoError(t, err)
+	assert.NotEqual(t, sibling.X, other.X, "different indexes should derive different keys")
+	assert.NotEqual(t, sibling.ChainCode, other.ChainCode)
+
+	_, _, err = DeriveEd25519ChildKey(HardenedKeyStart, master)
+	assert.Error(t, err, "hardened derivation is impossible")
+}
@@ -0,0 +1,187 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofPDLwSlackBytesParts = 8
+)
+
+type (
+	// ProofPDLwSlack proves that the plaintext of a Paillier ciphertext c is the discrete logarithm of X to the base R,
+	// up to the slack allowed by the range check. It is the "PDL w/ slack" proof of GG20 section 4.3.
+	ProofPDLwSlack struct {
+		Z  *big.Int
+		U1 *crypto.ECPoint
+		U2, U3,
+		S1, S2, S3 *big.Int
+	}
+)
+
+// ProvePDLwSlack proves that c = Enc(m; r) under `pk` and X = m * R, with the verifier's NTilde, h1 and h2.
+func ProvePDLwSlack(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h2 *big.Int, R, X *crypto.ECPoint, m, r *big.Int, rand io.Reader) (*ProofPDLwSlack, error) {
+	if ec == nil || pk == nil || c == nil || NTilde == nil || h1 == nil || h2 == nil || R == nil || X == nil || m == nil || r == nil {
+		return nil, errors.New("ProvePDLwSlack constructor received nil value(s)")
+	}
+
+	q := ec.Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+	qNTilde := new(big.Int).Mul(q, NTilde)
+	q3NTilde := new(big.Int).Mul(q3, NTilde)
+
+	alpha := common.GetRandomPositiveInt(rand, q3)
+	beta := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	rho := common.GetRandomPositiveInt(rand, qNTilde)
+	gamma := common.GetRandomPositiveInt(rand, q3NTilde)
+
+	// z = h1^m * h2^rho
+	modNTilde := common.ModInt(NTilde)
+	z := modNTilde.Mul(modNTilde.Exp(h1, m), modNTilde.Exp(h2, rho))
+
+	// u1 = alpha * R
+	u1 := R.ScalarMult(new(big.Int).Mod(alpha, q))
+
+	// u2 = Gamma^alpha * beta^N
+	modNSquared := common.ModInt(pk.NSquare())
+	u2 := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), alpha), modNSquared.Exp(beta, pk.N))
+
+	// u3 = h1^alpha * h2^gamma
+	u3 := modNTilde.Mul(modNTilde.Exp(h1, alpha), modNTilde.Exp(h2, gamma))
+
+	e := pdlChallenge(Session, ec, pk, c, NTilde, h1, h2, R, X, z, u1, u2, u3)
+
+	// s1 = e * m + alpha
+	s1 := new(big.Int).Add(new(big.Int).Mul(e, m), alpha)
+
+	// s2 = r^e * beta
+	modN := common.ModInt(pk.N)
+	s2 := modN.Mul(modN.Exp(r, e), beta)
+
+	// s3 = e * rho + gamma
+	s3 := new(big.Int).Add(new(big.Int).Mul(e, rho), gamma)
+
+	return &ProofPDLwSlack{Z: z, U1: u1, U2: u2, U3: u3, S1: s1, S2: s2, S3: s3}, nil
+}
+
+func ProofPDLwSlackFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofPDLwSlack, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofPDLwSlackBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofPDLwSlack", ProofPDLwSlackBytesParts)
+	}
+	u1, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(bzs[1]), new(big.Int).SetBytes(bzs[2]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofPDLwSlack{
+		Z:  new(big.Int).SetBytes(bzs[0]),
+		U1: u1,
+		U2: new(big.Int).SetBytes(bzs[3]),
+		U3: new(big.Int).SetBytes(bzs[4]),
+		S1: new(big.Int).SetBytes(bzs[5]),
+		S2: new(big.Int).SetBytes(bzs[6]),
+		S3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+func (pf *ProofPDLwSlack) Verify(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h2 *big.Int, R, X *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk == nil || c == nil || NTilde == nil || h1 == nil || h2 == nil || R == nil || X == nil {
+		return false
+	}
+
+	q := ec.Params().N
+	q3 := new(big.Int).Mul(q, q)
+	q3 = new(big.Int).Mul(q, q3)
+
+	if !common.IsInInterval(pf.Z, NTilde) || !common.IsInInterval(pf.U3, NTilde) {
+		return false
+	}
+	if !common.IsInInterval(pf.U2, pk.NSquare()) || !common.IsInInterval(pf.S2, pk.N) {
+		return false
+	}
+	if new(big.Int).GCD(nil, nil, pf.Z, NTilde).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.U3, NTilde).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.U2, pk.NSquare()).Cmp(one) != 0 ||
+		new(big.Int).GCD(nil, nil, pf.S2, pk.N).Cmp(one) != 0 {
+		return false
+	}
+	// the range check that bounds the slack
+	if pf.S1.Cmp(q3) == 1 {
+		return false
+	}
+
+	e := pdlChallenge(Session, ec, pk, c, NTilde, h1, h2, R, X, pf.Z, pf.U1, pf.U2, pf.U3)
+	minusE := new(big.Int).Sub(zero, e)
+
+	{ // u1 = s1 * R - e * X
+		s1R := R.ScalarMult(new(big.Int).Mod(pf.S1, q))
+		eX := X.ScalarMult(new(big.Int).Mod(minusE, q))
+		rhs, err := s1R.Add(eX)
+		if err != nil || !pf.U1.Equals(rhs) {
+			return false
+		}
+	}
+
+	{ // u2 = Gamma^s1 * s2^N * c^-e
+		modNSquared := common.ModInt(pk.NSquare())
+		rhs := modNSquared.Mul(modNSquared.Exp(pk.Gamma(), pf.S1), modNSquared.Exp(pf.S2, pk.N))
+		rhs = modNSquared.Mul(rhs, modNSquared.Exp(c, minusE))
+		if pf.U2.Cmp(rhs) != 0 {
+			return false
+		}
+	}
+
+	{ // u3 = h1^s1 * h2^s3 * z^-e
+		modNTilde := common.ModInt(NTilde)
+		rhs := modNTilde.Mul(modNTilde.Exp(h1, pf.S1), modNTilde.Exp(h2, pf.S3))
+		rhs = modNTilde.Mul(rhs, modNTilde.Exp(pf.Z, minusE))
+		if pf.U3.Cmp(rhs) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPDLwSlack) ValidateBasic() bool {
+	return pf.Z != nil &&
+		pf.U1 != nil &&
+		pf.U2 != nil &&
+		pf.U3 != nil &&
+		pf.S1 != nil &&
+		pf.S2 != nil &&
+		pf.S3 != nil
+}
+
+func (pf *ProofPDLwSlack) Bytes() [ProofPDLwSlackBytesParts][]byte {
+	return [...][]byte{
+		pf.Z.Bytes(),
+		pf.U1.X().Bytes(),
+		pf.U1.Y().Bytes(),
+		pf.U2.Bytes(),
+		pf.U3.Bytes(),
+		pf.S1.Bytes(),
+		pf.S2.Bytes(),
+		pf.S3.Bytes(),
+	}
+}
+
+func pdlChallenge(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h2 *big.Int, R, X *crypto.ECPoint, z *big.Int, u1 *crypto.ECPoint, u2, u3 *big.Int) *big.Int {
+	// must use RejectionSample
+	in := append(pk.AsInts(), c, NTilde, h1, h2, R.X(), R.Y(), X.X(), X.Y(), z, u1.X(), u1.Y(), u2, u3)
+	eHash := common.SHA512_256i_TAGGED(Session, in...)
+	return common.RejectionSample(ec.Params().N, eHash)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestProvePDLwSlack(t *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	session := []byte("session")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, testPaillierKeyLength)
+	assert.NoError(t, err)
+
+	m := common.GetRandomPositiveInt(rand.Reader, q)
+	c, r, err := pk.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	R := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	X := R.ScalarMult(m)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NTilde, h1, h2, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(t, err)
+
+	proof, err := ProvePDLwSlack(session, ec, pk, c, NTilde, h1, h2, R, X, m, r, rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(session, ec, pk, c, NTilde, h1, h2, R, X), "proof must verify")
+
+	bzs := proof.Bytes()
+	decoded, err := ProofPDLwSlackFromBytes(ec, bzs[:])
+	assert.NoError(t, err)
+	assert.True(t, decoded.Verify(session, ec, pk, c, NTilde, h1, h2, R, X), "decoded proof must verify")
+
+	assert.False(t, proof.Verify([]byte("other session"), ec, pk, c, NTilde, h1, h2, R, X), "proof must not verify in another session")
+	assert.False(t, proof.Verify(session, ec, pk, c, NTilde, h1, h2, R, X.ScalarMult(big.NewInt(2))), "proof must not verify for another point")
+
+	// the point does not match the plaintext
+	bad, err := ProvePDLwSlack(session, ec, pk, c, NTilde, h1, h2, R, R, m, r, rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, bad.Verify(session, ec, pk, c, NTilde, h1, h2, R, R), "proof of a wrong point must not verify")
+}
@@ -79,22 +79,28 @@ func GenerateKeyPair(ctx context.Context, rand io.Reader, modulusBitLen int, opt
 	}
 
 	// KS-BTL-F-03: use two safe primes for P, Q
-	var P, Q, N *big.Int
-	{
-		tmp := new(big.Int)
-		for {
-			sgps, err := common.GetRandomSafePrimesConcurrent(ctx, modulusBitLen/2, 2, concurrency, rand)
-			if err != nil {
-				return nil, nil, err
-			}
-			P, Q = sgps[0].SafePrime(), sgps[1].SafePrime()
-			// KS-BTL-F-03: check that p-q is also very large in order to avoid square-root attacks
-			if tmp.Sub(P, Q).BitLen() >= (modulusBitLen/2)-pQBitLenDifference {
-				break
-			}
+	for {
+		sgps, err := common.GetRandomSafePrimesConcurrent(ctx, modulusBitLen/2, 2, concurrency, rand)
+		if err != nil {
+			return nil, nil, err
 		}
-		N = tmp.Mul(P, Q)
+		if privateKey, err = NewPrivateKeyFromSafePrimes(sgps[0].SafePrime(), sgps[1].SafePrime()); err == nil {
+			return privateKey, &privateKey.PublicKey, nil
+		}
+	}
+}
+
+// NewPrivateKeyFromSafePrimes builds the private key for the modulus N = P*Q of two safe primes of equal length.
+// The caller is responsible for P and Q being safe primes; an error is returned if P-Q is too small.
+func NewPrivateKeyFromSafePrimes(P, Q *big.Int) (*PrivateKey, error) {
+	if P == nil || Q == nil || P.BitLen() != Q.BitLen() {
+		return nil, errors.New("P and Q must be of equal bit length")
 	}
+	// KS-BTL-F-03: check that p-q is also very large in order to avoid square-root attacks
+	if new(big.Int).Sub(P, Q).BitLen() < P.BitLen()-pQBitLenDifference {
+		return nil, errors.New("P and Q are too close to each other")
+	}
+	N := new(big.Int).Mul(P, Q)
 
 	// phiN = P-1 * Q-1
 	PMinus1, QMinus1 := new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one)
@@ -104,9 +110,7 @@ func GenerateKeyPair(ctx context.Context, rand io.Reader, modulusBitLen int, opt
 	gcd := new(big.Int).GCD(nil, nil, PMinus1, QMinus1)
 	lambdaN := new(big.Int).Div(phiN, gcd)
 
-	publicKey = &PublicKey{N: N}
-	privateKey = &PrivateKey{PublicKey: *publicKey, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}
-	return
+	return &PrivateKey{PublicKey: PublicKey{N: N}, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}, nil
 }
 
 // ----- //
@@ -116,14 +120,25 @@ func (publicKey *PublicKey) EncryptAndReturnRandomness(rand io.Reader, m *big.In
 		return nil, nil, ErrMessageTooLong
 	}
 	x = common.GetRandomPositiveRelativelyPrimeInt(rand, publicKey.N)
+	c, err = publicKey.EncryptWithRandomness(m, x)
+	return
+}
+
+// EncryptWithRandomness encrypts `m` with the randomness `x`, e.g. to check an encryption whose randomness was revealed
+func (publicKey *PublicKey) EncryptWithRandomness(m, x *big.Int) (*big.Int, error) {
+	if m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
+	if x.Cmp(one) == -1 || x.Cmp(publicKey.N) != -1 { // x < 1 || x >= N ?
+		return nil, errors.New("the randomness is out of range")
+	}
 	N2 := publicKey.NSquare()
 	// 1. gamma^m mod N2
 	Gm := new(big.Int).Exp(publicKey.Gamma(), m, N2)
 	// 2. x^N mod N2
 	xN := new(big.Int).Exp(x, publicKey.N, N2)
 	// 3. (1) * (2) mod N2
-	c = common.ModInt(N2).Mul(Gm, xN)
-	return
+	return common.ModInt(N2).Mul(Gm, xN), nil
 }
 
 func (publicKey *PublicKey) Encrypt(rand io.Reader, m *big.Int) (c *big.Int, err error) {
@@ -76,6 +76,21 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestEncryptWithRandomness(t *testing.T) {
+	setUp(t)
+	m := big.NewInt(100)
+	cypher, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	again, err := publicKey.EncryptWithRandomness(m, x)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cypher.Cmp(again), "the same randomness should give the same ciphertext")
+
+	_, err = publicKey.EncryptWithRandomness(m, big.NewInt(0))
+	assert.Error(t, err)
+	_, err = publicKey.EncryptWithRandomness(m, publicKey.N)
+	assert.Error(t, err)
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(rand.Reader, big.NewInt(3))
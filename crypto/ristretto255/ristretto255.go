@@ -0,0 +1,169 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ristretto255 encodes points of edwards25519 as elements of the ristretto255 group of RFC 9496. The elements
+// of ristretto255 are the cosets of the 4-torsion of edwards25519, so its arithmetic is that of the edwards points in
+// the prime-order subgroup, e.g. as generated by EdDSA keygen, and its generator is the Ed25519 base point. Only the
+// encoding differs: every point of a coset encodes to the same 32 bytes.
+//
+// github.com/gtank/ristretto255 keeps its points opaque and cannot take the edwards25519 points of the key shares, so
+// the encoding is implemented here on crypto.ECPoint. It is tested against the vectors of RFC 9496 and against that
+// package.
+package ristretto255
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// EncodedLen is the length of an encoded element
+const EncodedLen = 32
+
+var (
+	p = tss.Edwards().Params().P
+
+	// the constants of RFC 9496, section 4.1
+	one          = big.NewInt(1)
+	d            = new(big.Int).Mod(new(big.Int).Mul(big.NewInt(-121665), new(big.Int).ModInverse(big.NewInt(121666), p)), p)
+	sqrtM1       = new(big.Int).Exp(big.NewInt(2), new(big.Int).Rsh(new(big.Int).Sub(p, one), 2), p)
+	invSqrtAMinD = func() *big.Int {
+		_, r := sqrtRatioM1(one, fieldSub(new(big.Int).Sub(p, one), d))
+		return r
+	}()
+	pMinus5Div8 = new(big.Int).Rsh(new(big.Int).Sub(p, big.NewInt(5)), 3)
+)
+
+// Encode returns the 32-byte encoding of the ristretto255 element of `point`, a point of edwards25519
+func Encode(point *crypto.ECPoint) []byte {
+	x0, y0 := point.X(), point.Y()
+	t0 := fieldMul(x0, y0)
+
+	// the encoding of RFC 9496, section 4.3.2, with Z0 = 1
+	u1 := fieldMul(fieldAdd(one, y0), fieldSub(one, y0))
+	u2 := fieldMul(x0, y0)
+	_, invSqrt := sqrtRatioM1(one, fieldMul(u1, fieldMul(u2, u2)))
+	den1 := fieldMul(invSqrt, u1)
+	den2 := fieldMul(invSqrt, u2)
+	zInv := fieldMul(fieldMul(den1, den2), t0)
+
+	x, y, denInv := x0, y0, den2
+	if isNegative(fieldMul(t0, zInv)) {
+		x, y = fieldMul(y0, sqrtM1), fieldMul(x0, sqrtM1)
+		denInv = fieldMul(den1, invSqrtAMinD)
+	}
+	if isNegative(fieldMul(x, zInv)) {
+		y = fieldNeg(y)
+	}
+	s := fieldAbs(fieldMul(denInv, fieldSub(one, y)))
+	return toLittleEndian(s)
+}
+
+// Decode returns a point of edwards25519 in the coset that `bz` encodes. It fails unless `bz` is the canonical
+// encoding of an element.
+func Decode(bz []byte) (*crypto.ECPoint, error) {
+	if len(bz) != EncodedLen {
+		return nil, errors.New("ristretto255: an encoding must be 32 bytes")
+	}
+	s := fromLittleEndian(bz)
+	if s.Cmp(p) >= 0 || isNegative(s) {
+		return nil, errors.New("ristretto255: non-canonical encoding")
+	}
+
+	// the decoding of RFC 9496, section 4.3.1
+	ss := fieldMul(s, s)
+	u1 := fieldSub(one, ss)
+	u2 := fieldAdd(one, ss)
+	u2Sqr := fieldMul(u2, u2)
+	v := fieldSub(fieldNeg(fieldMul(d, fieldMul(u1, u1))), u2Sqr)
+	wasSquare, invSqrt := sqrtRatioM1(one, fieldMul(v, u2Sqr))
+	denX := fieldMul(invSqrt, u2)
+	denY := fieldMul(fieldMul(invSqrt, denX), v)
+	x := fieldAbs(fieldMul(fieldMul(big.NewInt(2), s), denX))
+	y := fieldMul(u1, denY)
+	if !wasSquare || isNegative(fieldMul(x, y)) || y.Sign() == 0 {
+		return nil, errors.New("ristretto255: invalid encoding")
+	}
+	return crypto.NewECPoint(tss.Edwards(), x, y)
+}
+
+// Equal reports whether `a` and `b` are the same ristretto255 element, i.e. in the same coset of the 4-torsion
+func Equal(a, b *crypto.ECPoint) bool {
+	// X1*Y2 == Y1*X2 or Y1*Y2 == X1*X2, RFC 9496 section 4.3.3
+	return fieldMul(a.X(), b.Y()).Cmp(fieldMul(a.Y(), b.X())) == 0 ||
+		fieldMul(a.Y(), b.Y()).Cmp(fieldMul(a.X(), b.X())) == 0
+}
+
+// IsIdentity reports whether `a` is the identity element, i.e. one of the points of the 4-torsion
+func IsIdentity(a *crypto.ECPoint) bool {
+	return a.X().Sign() == 0 || a.Y().Sign() == 0
+}
+
+// ----- //
+
+// sqrtRatioM1 returns whether u/v is a square and the non-negative square root of u/v if it is, of SQRT_M1*u/v
+// otherwise, as in RFC 9496 section 4.2
+func sqrtRatioM1(u, v *big.Int) (bool, *big.Int) {
+	v3 := fieldMul(fieldMul(v, v), v)
+	v7 := fieldMul(fieldMul(v3, v3), v)
+	r := fieldMul(fieldMul(u, v3), new(big.Int).Exp(fieldMul(u, v7), pMinus5Div8, p))
+	check := fieldMul(v, fieldMul(r, r))
+
+	correctSignSqrt := check.Cmp(u) == 0
+	flippedSignSqrt := check.Cmp(fieldNeg(u)) == 0
+	flippedSignSqrtI := check.Cmp(fieldNeg(fieldMul(u, sqrtM1))) == 0
+	if flippedSignSqrt || flippedSignSqrtI {
+		r = fieldMul(r, sqrtM1)
+	}
+	return correctSignSqrt || flippedSignSqrt, fieldAbs(r)
+}
+
+func fieldAdd(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Add(a, b), p)
+}
+
+func fieldSub(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Sub(a, b), p)
+}
+
+func fieldMul(a, b *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Mul(a, b), p)
+}
+
+func fieldNeg(a *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).Neg(a), p)
+}
+
+// isNegative reports whether the canonical encoding of `a` has its least significant bit set
+func isNegative(a *big.Int) bool {
+	return new(big.Int).Mod(a, p).Bit(0) == 1
+}
+
+func fieldAbs(a *big.Int) *big.Int {
+	if isNegative(a) {
+		return fieldNeg(a)
+	}
+	return new(big.Int).Mod(a, p)
+}
+
+func toLittleEndian(a *big.Int) []byte {
+	bz := make([]byte, EncodedLen)
+	a.FillBytes(bz)
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+	return bz
+}
+
+func fromLittleEndian(bz []byte) *big.Int {
+	be := make([]byte, len(bz))
+	for i := range bz {
+		be[len(bz)-1-i] = bz[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
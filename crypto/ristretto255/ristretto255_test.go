@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ristretto255
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	gtank "github.com/gtank/ristretto255"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the multiples of the generator in RFC 9496, appendix A.1
+var generatorMultiples = []string{
+	"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+	"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+	"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+}
+
+func TestEncodeDecode(t *testing.T) {
+	ec := tss.Edwards()
+	for i, expected := range generatorMultiples {
+		point := crypto.ScalarBaseMult(ec, big.NewInt(int64(i+1)))
+		assert.Equal(t, expected, hex.EncodeToString(Encode(point)), "%d·B", i+1)
+
+		decoded, err := Decode(Encode(point))
+		assert.NoError(t, err)
+		assert.True(t, Equal(point, decoded), "%d·B", i+1)
+	}
+
+	for i := 0; i < 10; i++ {
+		point := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, ec.Params().N))
+		decoded, err := Decode(Encode(point))
+		assert.NoError(t, err)
+		assert.True(t, Equal(point, decoded))
+		assert.Equal(t, Encode(point), Encode(decoded))
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	// non-canonical and invalid encodings from RFC 9496, appendix A.2
+	for _, bad := range []string{
+		"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	} {
+		bz, _ := hex.DecodeString(bad)
+		_, err := Decode(bz)
+		assert.Error(t, err, bad)
+	}
+	_, err := Decode(make([]byte, 31))
+	assert.Error(t, err)
+}
+
+// TestMatchesGtank checks the encoding against github.com/gtank/ristretto255, which cannot be used in its place as it
+// does not take the points of edwards25519 that EdDSA keygen outputs
+func TestMatchesGtank(t *testing.T) {
+	ec := tss.Edwards()
+	for i := 0; i < 10; i++ {
+		s := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		sBytes := s.FillBytes(make([]byte, 32))
+		for a, b := 0, len(sBytes)-1; a < b; a, b = a+1, b-1 {
+			sBytes[a], sBytes[b] = sBytes[b], sBytes[a]
+		}
+		scalar := gtank.NewScalar()
+		if !assert.NoError(t, scalar.Decode(sBytes)) {
+			return
+		}
+		expected := gtank.NewElement().ScalarBaseMult(scalar).Encode(nil)
+
+		point := crypto.ScalarBaseMult(ec, s)
+		assert.Equal(t, expected, Encode(point), "the encoding of s·B")
+		decoded, err := Decode(expected)
+		if assert.NoError(t, err) {
+			assert.True(t, Equal(point, decoded), "the decoding of s·B")
+		}
+	}
+}
@@ -25,6 +25,18 @@ type (
 		Alpha *crypto.ECPoint
 		T, U  *big.Int
 	}
+
+	// ZKDLEQProof is a Chaum-Pedersen proof that X = x*G and Y = x*H for the same x
+	ZKDLEQProof struct {
+		Alpha, Beta *crypto.ECPoint
+		T           *big.Int
+	}
+
+	// ZKSTProof proves knowledge of sigma and l such that S = sigma*R and T = sigma*G + l*R (GG20 section 4.3)
+	ZKSTProof struct {
+		Alpha, Beta *crypto.ECPoint
+		T, U        *big.Int
+	}
 )
 
 // NewZKProof constructs a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
@@ -135,3 +147,107 @@ func (pf *ZKVProof) Verify(Session []byte, V, R *crypto.ECPoint) bool {
 func (pf *ZKVProof) ValidateBasic() bool {
 	return pf.Alpha != nil && pf.T != nil && pf.U != nil && pf.Alpha.ValidateBasic()
 }
+
+// NewZKDLEQProof constructs a Chaum-Pedersen ZK proof of knowledge of x such that X = x*G and Y = x*H
+func NewZKDLEQProof(Session []byte, x *big.Int, X, H, Y *crypto.ECPoint, rand io.Reader) (*ZKDLEQProof, error) {
+	if x == nil || X == nil || H == nil || Y == nil || !X.ValidateBasic() || !H.ValidateBasic() || !Y.ValidateBasic() {
+		return nil, errors.New("ZKDLEQProof constructor received nil or invalid value(s)")
+	}
+	q := X.Curve().Params().N
+
+	a := common.GetRandomPositiveInt(rand, q)
+	alpha := crypto.ScalarBaseMult(X.Curve(), a)
+	beta := H.ScalarMult(a)
+
+	c := DLEQChallenge(Session, X, H, Y, alpha, beta)
+	t := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+
+	return &ZKDLEQProof{Alpha: alpha, Beta: beta, T: t}, nil
+}
+
+// DLEQChallenge returns the challenge of a ZKDLEQProof with the commitments Alpha = a*G and Beta = a*H. It is exported
+// for proofs that are computed jointly, in which each party adds its share of a and of T.
+func DLEQChallenge(Session []byte, X, H, Y, Alpha, Beta *crypto.ECPoint) *big.Int {
+	ecParams := X.Curve().Params()
+	cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), H.X(), H.Y(), Y.X(), Y.Y(), ecParams.Gx, ecParams.Gy,
+		Alpha.X(), Alpha.Y(), Beta.X(), Beta.Y())
+	return common.RejectionSample(ecParams.N, cHash)
+}
+
+// Verify verifies a Chaum-Pedersen ZK proof that X = x*G and Y = x*H for the same x
+func (pf *ZKDLEQProof) Verify(Session []byte, X, H, Y *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || !X.ValidateBasic() || !H.ValidateBasic() || !Y.ValidateBasic() {
+		return false
+	}
+	c := DLEQChallenge(Session, X, H, Y, pf.Alpha, pf.Beta)
+
+	aXc, err := pf.Alpha.Add(X.ScalarMult(c))
+	if err != nil || !crypto.ScalarBaseMult(X.Curve(), pf.T).Equals(aXc) {
+		return false
+	}
+	bYc, err := pf.Beta.Add(Y.ScalarMult(c))
+	if err != nil || !H.ScalarMult(pf.T).Equals(bYc) {
+		return false
+	}
+	return true
+}
+
+func (pf *ZKDLEQProof) ValidateBasic() bool {
+	return pf.Alpha != nil && pf.Beta != nil && pf.T != nil && pf.Alpha.ValidateBasic() && pf.Beta.ValidateBasic()
+}
+
+// NewZKSTProof constructs a ZK proof of knowledge of sigma, l such that S = sigma*R and T = sigma*G + l*R
+func NewZKSTProof(Session []byte, S, T, R *crypto.ECPoint, sigma, l *big.Int, rand io.Reader) (*ZKSTProof, error) {
+	if S == nil || T == nil || R == nil || sigma == nil || l == nil || !S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() {
+		return nil, errors.New("ZKSTProof constructor received nil or invalid value(s)")
+	}
+	ec := R.Curve()
+	q := ec.Params().N
+
+	a, b := common.GetRandomPositiveInt(rand, q), common.GetRandomPositiveInt(rand, q)
+	alpha := R.ScalarMult(a)
+	beta, err := crypto.ScalarBaseMult(ec, a).Add(R.ScalarMult(b))
+	if err != nil {
+		return nil, err
+	}
+
+	c := stChallenge(Session, S, T, R, alpha, beta)
+	modQ := common.ModInt(q)
+	t := modQ.Add(a, new(big.Int).Mul(c, sigma))
+	u := modQ.Add(b, new(big.Int).Mul(c, l))
+
+	return &ZKSTProof{Alpha: alpha, Beta: beta, T: t, U: u}, nil
+}
+
+// Verify verifies a ZK proof that S = sigma*R and T = sigma*G + l*R for the same sigma
+func (pf *ZKSTProof) Verify(Session []byte, S, T, R *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || !S.ValidateBasic() || !T.ValidateBasic() || !R.ValidateBasic() {
+		return false
+	}
+	c := stChallenge(Session, S, T, R, pf.Alpha, pf.Beta)
+
+	aSc, err := pf.Alpha.Add(S.ScalarMult(c))
+	if err != nil || !R.ScalarMult(pf.T).Equals(aSc) {
+		return false
+	}
+	tGuR, err := crypto.ScalarBaseMult(R.Curve(), pf.T).Add(R.ScalarMult(pf.U))
+	if err != nil {
+		return false
+	}
+	bTc, err := pf.Beta.Add(T.ScalarMult(c))
+	if err != nil || !tGuR.Equals(bTc) {
+		return false
+	}
+	return true
+}
+
+func (pf *ZKSTProof) ValidateBasic() bool {
+	return pf.Alpha != nil && pf.Beta != nil && pf.T != nil && pf.U != nil && pf.Alpha.ValidateBasic() && pf.Beta.ValidateBasic()
+}
+
+func stChallenge(Session []byte, S, T, R, Alpha, Beta *crypto.ECPoint) *big.Int {
+	ecParams := R.Curve().Params()
+	cHash := common.SHA512_256i_TAGGED(Session, S.X(), S.Y(), T.X(), T.Y(), R.X(), R.Y(), ecParams.Gx, ecParams.Gy,
+		Alpha.X(), Alpha.Y(), Beta.X(), Beta.Y())
+	return common.RejectionSample(ecParams.N, cHash)
+}
@@ -103,3 +103,59 @@ func TestSchnorrVProofVerifyBadS(t *testing.T) {
 
 	assert.False(t, res, "verify result must be false")
 }
+
+func TestDLEQProofVerify(t *testing.T) {
+	q := tss.EC().Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	h := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(tss.EC(), x)
+	H := crypto.ScalarBaseMult(tss.EC(), h)
+	Y := H.ScalarMult(x)
+
+	proof, err := NewZKDLEQProof(Session, x, X, H, Y, rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(Session, X, H, Y), "verify result must be true")
+	assert.False(t, proof.Verify([]byte("another session"), X, H, Y), "verify result must be false")
+}
+
+func TestDLEQProofVerifyBadY(t *testing.T) {
+	q := tss.EC().Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	x2 := common.GetRandomPositiveInt(rand.Reader, q)
+	h := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(tss.EC(), x)
+	H := crypto.ScalarBaseMult(tss.EC(), h)
+	Y := H.ScalarMult(x2)
+
+	proof, err := NewZKDLEQProof(Session, x, X, H, Y, rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(Session, X, H, Y), "verify result must be false")
+}
+
+func TestSTProofVerify(t *testing.T) {
+	q := tss.EC().Params().N
+	sigma := common.GetRandomPositiveInt(rand.Reader, q)
+	l := common.GetRandomPositiveInt(rand.Reader, q)
+	R := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	S := R.ScalarMult(sigma)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), sigma).Add(R.ScalarMult(l))
+
+	proof, err := NewZKSTProof(Session, S, T, R, sigma, l, rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, proof.Verify(Session, S, T, R), "verify result must be true")
+	assert.False(t, proof.Verify([]byte("another session"), S, T, R), "verify result must be false")
+}
+
+func TestSTProofVerifyBadS(t *testing.T) {
+	q := tss.EC().Params().N
+	sigma := common.GetRandomPositiveInt(rand.Reader, q)
+	sigma2 := common.GetRandomPositiveInt(rand.Reader, q)
+	l := common.GetRandomPositiveInt(rand.Reader, q)
+	R := crypto.ScalarBaseMult(tss.EC(), common.GetRandomPositiveInt(rand.Reader, q))
+	S := R.ScalarMult(sigma2)
+	T, _ := crypto.ScalarBaseMult(tss.EC(), sigma).Add(R.ScalarMult(l))
+
+	proof, err := NewZKSTProof(Session, S, T, R, sigma, l, rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, proof.Verify(Session, S, T, R), "verify result must be false")
+}
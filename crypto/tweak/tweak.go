@@ -0,0 +1,121 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tweak
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Key tweaks for threshold signing. A tweak maps the key x to mul*x + add, where mul and add may depend on the public
+// key being tweaked, e.g. t = H(P || contract) for a pay-to-contract commitment. Any sequence of tweaks composes into a
+// single such map, which the signers apply to their Shamir shares and to the public shares of the others: the Lagrange
+// coefficients of a signing set sum to one, so mul*x_i + add are shares of mul*x + add.
+
+// Tweak is one step of a key tweak. Apply returns mul and add for which the key with the public key `pub`, the result
+// of the previous steps, is tweaked to mul*x + add.
+type Tweak interface {
+	Apply(pub *crypto.ECPoint) (mul, add *big.Int, err error)
+}
+
+// Func is a Tweak computed by a function of the public key, for tweak schemes that are not built in
+type Func func(pub *crypto.ECPoint) (mul, add *big.Int, err error)
+
+// Affine is the composition of a sequence of tweaks, which maps the key x to Mul*x + Add. PublicKey is the tweaked
+// public key.
+type Affine struct {
+	Mul, Add  *big.Int
+	PublicKey *crypto.ECPoint
+}
+
+func (f Func) Apply(pub *crypto.ECPoint) (*big.Int, *big.Int, error) {
+	return f(pub)
+}
+
+// Additive returns the tweak x + t
+func Additive(t *big.Int) Tweak {
+	return Func(func(*crypto.ECPoint) (*big.Int, *big.Int, error) {
+		return big.NewInt(1), t, nil
+	})
+}
+
+// Multiplicative returns the tweak t * x
+func Multiplicative(t *big.Int) Tweak {
+	return Func(func(*crypto.ECPoint) (*big.Int, *big.Int, error) {
+		return t, big.NewInt(0), nil
+	})
+}
+
+// Compute applies `tweaks` in order to the public key `pub` and returns their composition. It fails if a tweak fails,
+// multiplies by zero or tweaks the key to the identity.
+func Compute(pub *crypto.ECPoint, tweaks ...Tweak) (*Affine, error) {
+	if pub == nil || !pub.ValidateBasic() {
+		return nil, errors.New("tweak.Compute expected a valid public key")
+	}
+	N := pub.Curve().Params().N
+	modN := common.ModInt(N)
+	affine := &Affine{Mul: big.NewInt(1), Add: big.NewInt(0), PublicKey: pub}
+	for _, t := range tweaks {
+		mul, add, err := t.Apply(affine.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		if mul == nil || add == nil {
+			return nil, errors.New("tweak.Compute: a tweak returned a nil mul or add")
+		}
+		step := &Affine{Mul: new(big.Int).Mod(mul, N), Add: new(big.Int).Mod(add, N)}
+		if step.Mul.Sign() == 0 {
+			return nil, errors.New("tweak.Compute: a tweak multiplies the key by zero")
+		}
+		pubStep, err := step.PublicShare(affine.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		// mul*(a*x + b) + add
+		affine = &Affine{
+			Mul:       modN.Mul(step.Mul, affine.Mul),
+			Add:       modN.Add(modN.Mul(step.Mul, affine.Add), step.Add),
+			PublicKey: pubStep,
+		}
+	}
+	return affine, nil
+}
+
+// Share returns the tweaked secret share Mul*xi + Add
+func (a *Affine) Share(xi *big.Int) *big.Int {
+	modN := common.ModInt(a.PublicKey.Curve().Params().N)
+	return modN.Add(modN.Mul(a.Mul, xi), a.Add)
+}
+
+// PublicShare returns the tweaked public share Mul*Xj + Add*G
+func (a *Affine) PublicShare(Xj *crypto.ECPoint) (*crypto.ECPoint, error) {
+	point := Xj
+	if a.Mul.Cmp(big.NewInt(1)) != 0 {
+		point = Xj.ScalarMult(a.Mul)
+	}
+	if a.Add.Sign() != 0 {
+		var err error
+		if point, err = point.Add(crypto.ScalarBaseMult(Xj.Curve(), a.Add)); err != nil {
+			return nil, err
+		}
+	}
+	if !point.IsOnCurve() || isIdentity(point) {
+		return nil, errors.New("the tweaked key is the identity")
+	}
+	return point, nil
+}
+
+// ----- //
+
+// isIdentity reports whether `p` is the point at infinity of a short Weierstrass curve, (0, 0), or the identity of
+// the edwards curve, (0, 1)
+func isIdentity(p *crypto.ECPoint) bool {
+	return p.X().Sign() == 0 && p.Y().Cmp(big.NewInt(1)) <= 0
+}
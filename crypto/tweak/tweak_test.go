@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tweak_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/tweak"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// payToContract commits to `contract` with the tweak x + H(P || contract)
+func payToContract(contract []byte) Tweak {
+	return Func(func(pub *crypto.ECPoint) (*big.Int, *big.Int, error) {
+		t := common.SHA512_256i(pub.X(), pub.Y(), new(big.Int).SetBytes(contract))
+		return big.NewInt(1), t, nil
+	})
+}
+
+func TestCompute(t *testing.T) {
+	for _, ec := range []tss.CurveName{tss.Secp256k1, tss.Ed25519} {
+		curve, _ := tss.GetCurveByName(ec)
+		N := curve.Params().N
+		x := common.GetRandomPositiveInt(rand.Reader, N)
+		X := crypto.ScalarBaseMult(curve, x)
+		t1 := common.GetRandomPositiveInt(rand.Reader, N)
+		t2 := common.GetRandomPositiveInt(rand.Reader, N)
+
+		affine, err := Compute(X, Additive(t1), Multiplicative(t2), payToContract([]byte("contract")))
+		if !assert.NoError(t, err) {
+			return
+		}
+		modN := common.ModInt(N)
+		y := modN.Mul(modN.Add(x, t1), t2)
+		Y := crypto.ScalarBaseMult(curve, y)
+		y = modN.Add(y, common.SHA512_256i(Y.X(), Y.Y(), new(big.Int).SetBytes([]byte("contract"))))
+		assert.True(t, crypto.ScalarBaseMult(curve, y).Equals(affine.PublicKey), "%s: the tweaked key", ec)
+		assert.Equal(t, 0, y.Cmp(affine.Share(x)), "%s: the tweaked secret", ec)
+
+		// the tweaked shares of a key are shares of the tweaked key
+		x1 := common.GetRandomPositiveInt(rand.Reader, N)
+		X1 := crypto.ScalarBaseMult(curve, x1)
+		share, err := affine.PublicShare(X1)
+		if assert.NoError(t, err) {
+			assert.True(t, crypto.ScalarBaseMult(curve, affine.Share(x1)).Equals(share), "%s: the tweaked share", ec)
+		}
+
+		none, err := Compute(X)
+		if assert.NoError(t, err) {
+			assert.True(t, X.Equals(none.PublicKey), "%s: no tweak leaves the key unchanged", ec)
+		}
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	ec := tss.S256()
+	N := ec.Params().N
+	x := common.GetRandomPositiveInt(rand.Reader, N)
+	X := crypto.ScalarBaseMult(ec, x)
+
+	_, err := Compute(nil)
+	assert.Error(t, err, "the public key is required")
+	_, err = Compute(X, Multiplicative(N))
+	assert.Error(t, err, "a tweak must not multiply by zero")
+	_, err = Compute(X, Additive(new(big.Int).Sub(N, x)))
+	assert.Error(t, err, "a tweak must not reach the identity")
+	failure := errors.New("failed")
+	_, err = Compute(X, Func(func(*crypto.ECPoint) (*big.Int, *big.Int, error) {
+		return nil, nil, failure
+	}))
+	assert.Equal(t, failure, err, "the error of a tweak should be returned")
+}
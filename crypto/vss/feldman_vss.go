@@ -19,6 +19,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 type (
@@ -42,17 +43,8 @@ var (
 
 // Check share ids of Shamir's Secret Sharing, return error if duplicate or 0 value found
 func CheckIndexes(ec elliptic.Curve, indexes []*big.Int) ([]*big.Int, error) {
-	visited := make(map[string]struct{})
-	for _, v := range indexes {
-		vMod := new(big.Int).Mod(v, ec.Params().N)
-		if vMod.Cmp(zero) == 0 {
-			return nil, errors.New("party index should not be 0")
-		}
-		vModStr := vMod.String()
-		if _, ok := visited[vModStr]; ok {
-			return nil, fmt.Errorf("duplicate indexes %s", vModStr)
-		}
-		visited[vModStr] = struct{}{}
+	if err := tss.CheckShareIndexes(ec, indexes); err != nil {
+		return nil, err
 	}
 	return indexes, nil
 }
@@ -173,3 +165,53 @@ func evaluatePolynomial(ec elliptic.Curve, threshold int, v []*big.Int, id *big.
 	}
 	return
 }
+
+// CheckPublicShares verifies that the public shares bigXs[j] = x_j*G held by the parties with the given indexes
+// lie on a single polynomial of the given degree whose constant term is pub, i.e. that every threshold+1 subset
+// of them interpolates to pub.
+func CheckPublicShares(ec elliptic.Curve, threshold int, indexes []*big.Int, bigXs []*crypto.ECPoint, pub *crypto.ECPoint) error {
+	if len(indexes) != len(bigXs) {
+		return fmt.Errorf("len(indexes) != len(bigXs) (%d != %d)", len(indexes), len(bigXs))
+	}
+	if threshold < 0 || len(indexes) <= threshold {
+		return ErrNumSharesBelowThreshold
+	}
+	if _, err := CheckIndexes(ec, indexes); err != nil {
+		return err
+	}
+	modQ := common.ModInt(ec.Params().N)
+	// interpolates the polynomial "in the exponent" through the first threshold+1 public shares and evaluates it at x
+	interpolate := func(x *big.Int) (*crypto.ECPoint, error) {
+		var result *crypto.ECPoint
+		for m := 0; m <= threshold; m++ {
+			lambda := one
+			for l := 0; l <= threshold; l++ {
+				if l == m {
+					continue
+				}
+				lambda = modQ.Mul(lambda, modQ.Mul(modQ.Sub(x, indexes[l]), modQ.ModInverse(modQ.Sub(indexes[m], indexes[l]))))
+			}
+			term := bigXs[m].SetCurve(ec).ScalarMult(lambda)
+			if result == nil {
+				result = term
+				continue
+			}
+			var err error
+			if result, err = result.Add(term); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+	y, err := interpolate(zero)
+	if err != nil || !y.Equals(pub) {
+		return errors.New("the public shares do not interpolate to the public key")
+	}
+	for j := threshold + 1; j < len(indexes); j++ {
+		Xj, err := interpolate(indexes[j])
+		if err != nil || !Xj.Equals(bigXs[j]) {
+			return fmt.Errorf("the public share %d is not consistent with the other public shares", j)
+		}
+	}
+	return nil
+}
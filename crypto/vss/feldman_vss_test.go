@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -115,3 +116,31 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestCheckPublicShares(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	_, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+
+	bigXs := make([]*crypto.ECPoint, num)
+	for i, share := range shares {
+		bigXs[i] = crypto.ScalarBaseMult(tss.EC(), share.Share)
+	}
+	pub := crypto.ScalarBaseMult(tss.EC(), secret)
+	assert.NoError(t, CheckPublicShares(tss.EC(), threshold, ids, bigXs, pub))
+
+	wrongPub := crypto.ScalarBaseMult(tss.EC(), big.NewInt(1))
+	assert.Error(t, CheckPublicShares(tss.EC(), threshold, ids, bigXs, wrongPub))
+
+	bigXs[num-1] = wrongPub
+	assert.Error(t, CheckPublicShares(tss.EC(), threshold, ids, bigXs, pub))
+	assert.Error(t, CheckPublicShares(tss.EC(), num, ids, bigXs, pub)) // not enough shares
+}
@@ -39,6 +39,13 @@ type KGRound1Message struct {
 	H2         []byte   `protobuf:"bytes,5,opt,name=h2,proto3" json:"h2,omitempty"`
 	Dlnproof_1 [][]byte `protobuf:"bytes,6,rep,name=dlnproof_1,json=dlnproof1,proto3" json:"dlnproof_1,omitempty"`
 	Dlnproof_2 [][]byte `protobuf:"bytes,7,rep,name=dlnproof_2,json=dlnproof2,proto3" json:"dlnproof_2,omitempty"`
+	// proof policy of the sender; a set flag means it will not send the corresponding Paillier proof
+	NoProofMod bool `protobuf:"varint,8,opt,name=no_proof_mod,json=noProofMod,proto3" json:"no_proof_mod,omitempty"`
+	NoProofFac bool `protobuf:"varint,9,opt,name=no_proof_fac,json=noProofFac,proto3" json:"no_proof_fac,omitempty"`
+	// batch keygen: the commitments of the additional keys
+	BatchCommitments [][]byte `protobuf:"bytes,10,rep,name=batch_commitments,json=batchCommitments,proto3" json:"batch_commitments,omitempty"`
+	// commitment to the sender's contribution to the BIP-32 chain code
+	ChainCodeCommitment []byte `protobuf:"bytes,11,opt,name=chain_code_commitment,json=chainCodeCommitment,proto3" json:"chain_code_commitment,omitempty"`
 }
 
 func (x *KGRound1Message) Reset() {
@@ -122,6 +129,34 @@ func (x *KGRound1Message) GetDlnproof_2() [][]byte {
 	return nil
 }
 
+func (x *KGRound1Message) GetNoProofMod() bool {
+	if x != nil {
+		return x.NoProofMod
+	}
+	return false
+}
+
+func (x *KGRound1Message) GetNoProofFac() bool {
+	if x != nil {
+		return x.NoProofFac
+	}
+	return false
+}
+
+func (x *KGRound1Message) GetBatchCommitments() [][]byte {
+	if x != nil {
+		return x.BatchCommitments
+	}
+	return nil
+}
+
+func (x *KGRound1Message) GetChainCodeCommitment() []byte {
+	if x != nil {
+		return x.ChainCodeCommitment
+	}
+	return nil
+}
+
 // Represents a P2P message sent to each party during Round 2 of the ECDSA TSS keygen protocol.
 type KGRound2Message1 struct {
 	state         protoimpl.MessageState
@@ -130,6 +165,8 @@ type KGRound2Message1 struct {
 
 	Share    []byte   `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
 	FacProof [][]byte `protobuf:"bytes,2,rep,name=facProof,proto3" json:"facProof,omitempty"`
+	// batch keygen: the shares of the additional keys
+	BatchShares [][]byte `protobuf:"bytes,3,rep,name=batch_shares,json=batchShares,proto3" json:"batch_shares,omitempty"`
 }
 
 func (x *KGRound2Message1) Reset() {
@@ -178,6 +215,13 @@ func (x *KGRound2Message1) GetFacProof() [][]byte {
 	return nil
 }
 
+func (x *KGRound2Message1) GetBatchShares() [][]byte {
+	if x != nil {
+		return x.BatchShares
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to each party during Round 2 of the ECDSA TSS keygen protocol.
 type KGRound2Message2 struct {
 	state         protoimpl.MessageState
@@ -186,6 +230,10 @@ type KGRound2Message2 struct {
 
 	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
 	ModProof     [][]byte `protobuf:"bytes,2,rep,name=modProof,proto3" json:"modProof,omitempty"`
+	// batch keygen: the de-commitments of the additional keys
+	Batch []*KGRound2Message2_BatchKey `protobuf:"bytes,3,rep,name=batch,proto3" json:"batch,omitempty"`
+	// de-commitment of the sender's contribution to the BIP-32 chain code
+	ChainCodeDeCommitment [][]byte `protobuf:"bytes,4,rep,name=chain_code_de_commitment,json=chainCodeDeCommitment,proto3" json:"chain_code_de_commitment,omitempty"`
 }
 
 func (x *KGRound2Message2) Reset() {
@@ -234,6 +282,20 @@ func (x *KGRound2Message2) GetModProof() [][]byte {
 	return nil
 }
 
+func (x *KGRound2Message2) GetBatch() []*KGRound2Message2_BatchKey {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *KGRound2Message2) GetChainCodeDeCommitment() [][]byte {
+	if x != nil {
+		return x.ChainCodeDeCommitment
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to each party during Round 3 of the ECDSA TSS keygen protocol.
 type KGRound3Message struct {
 	state         protoimpl.MessageState
@@ -282,13 +344,60 @@ func (x *KGRound3Message) GetPaillierProof() [][]byte {
 	return nil
 }
 
+type KGRound2Message2_BatchKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeCommitment [][]byte `protobuf:"bytes,1,rep,name=de_commitment,json=deCommitment,proto3" json:"de_commitment,omitempty"`
+}
+
+func (x *KGRound2Message2_BatchKey) Reset() {
+	*x = KGRound2Message2_BatchKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_keygen_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KGRound2Message2_BatchKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KGRound2Message2_BatchKey) ProtoMessage() {}
+
+func (x *KGRound2Message2_BatchKey) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_keygen_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KGRound2Message2_BatchKey.ProtoReflect.Descriptor instead.
+func (*KGRound2Message2_BatchKey) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_keygen_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *KGRound2Message2_BatchKey) GetDeCommitment() [][]byte {
+	if x != nil {
+		return x.DeCommitment
+	}
+	return nil
+}
+
 var File_protob_ecdsa_keygen_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_keygen_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x6b,
 	0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x62, 0x69, 0x6e,
 	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73,
-	0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0xc7, 0x01, 0x0a, 0x0f, 0x4b, 0x47, 0x52,
+	0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0xec, 0x02, 0x0a, 0x0f, 0x4b, 0x47, 0x52,
 	0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
 	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
@@ -301,21 +410,45 @@ var file_protob_ecdsa_keygen_proto_rawDesc = []byte{
 	0x5f, 0x31, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f,
 	0x6f, 0x66, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
 	0x32, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x64, 0x6c, 0x6e, 0x70, 0x72, 0x6f, 0x6f,
-	0x66, 0x32, 0x22, 0x44, 0x0a, 0x10, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x66, 0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
-	0x66, 0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x53, 0x0a, 0x10, 0x4b, 0x47, 0x52, 0x6f,
-	0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x23, 0x0a, 0x0d,
-	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x38, 0x0a,
-	0x0f, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f,
-	0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69,
-	0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x0e, 0x5a, 0x0c, 0x65, 0x63, 0x64, 0x73, 0x61,
-	0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x66, 0x32, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6d,
+	0x6f, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6e, 0x6f, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x4d, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x5f, 0x66, 0x61, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6e, 0x6f, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x46, 0x61, 0x63, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x10, 0x62, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x13, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x67, 0x0a, 0x10, 0x4b, 0x47, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x61, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73,
+	0x22, 0x8b, 0x02, 0x0a, 0x10, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x6f,
+	0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x6f,
+	0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x4c, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79,
+	0x67, 0x65, 0x6e, 0x2e, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x32, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b, 0x65, 0x79, 0x52, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x37, 0x0a, 0x18, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x5f, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x15, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64,
+	0x65, 0x44, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x2f, 0x0a,
+	0x08, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38,
+	0x0a, 0x0f, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x70, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x0e, 0x5a, 0x0c, 0x65, 0x63, 0x64, 0x73,
+	0x61, 0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -330,19 +463,21 @@ func file_protob_ecdsa_keygen_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_keygen_proto_rawDescData
 }
 
-var file_protob_ecdsa_keygen_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_ecdsa_keygen_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_protob_ecdsa_keygen_proto_goTypes = []interface{}{
-	(*KGRound1Message)(nil),  // 0: binance.tsslib.ecdsa.keygen.KGRound1Message
-	(*KGRound2Message1)(nil), // 1: binance.tsslib.ecdsa.keygen.KGRound2Message1
-	(*KGRound2Message2)(nil), // 2: binance.tsslib.ecdsa.keygen.KGRound2Message2
-	(*KGRound3Message)(nil),  // 3: binance.tsslib.ecdsa.keygen.KGRound3Message
+	(*KGRound1Message)(nil),           // 0: binance.tsslib.ecdsa.keygen.KGRound1Message
+	(*KGRound2Message1)(nil),          // 1: binance.tsslib.ecdsa.keygen.KGRound2Message1
+	(*KGRound2Message2)(nil),          // 2: binance.tsslib.ecdsa.keygen.KGRound2Message2
+	(*KGRound3Message)(nil),           // 3: binance.tsslib.ecdsa.keygen.KGRound3Message
+	(*KGRound2Message2_BatchKey)(nil), // 4: binance.tsslib.ecdsa.keygen.KGRound2Message2.BatchKey
 }
 var file_protob_ecdsa_keygen_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4, // 0: binance.tsslib.ecdsa.keygen.KGRound2Message2.batch:type_name -> binance.tsslib.ecdsa.keygen.KGRound2Message2.BatchKey
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_protob_ecdsa_keygen_proto_init() }
@@ -399,6 +534,18 @@ func file_protob_ecdsa_keygen_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_keygen_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KGRound2Message2_BatchKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -406,7 +553,7 @@ func file_protob_ecdsa_keygen_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_keygen_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
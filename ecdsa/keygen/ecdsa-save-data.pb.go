@@ -0,0 +1,543 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/ecdsa-save-data.proto
+
+package keygen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Versioned container for the ECDSA LocalPartySaveData that each party persists after keygen or re-sharing.
+// Big integers are encoded as unsigned big-endian bytes; an empty value represents an unset (nil) integer.
+type PersistedSaveData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Schema version of this record; readers must reject versions they do not know
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Name of the curve in the tss curve registry, applies to all points in this record
+	Curve string `protobuf:"bytes,2,opt,name=curve,proto3" json:"curve,omitempty"`
+	// LocalPreParams
+	PaillierSk *PersistedSaveData_PaillierPrivateKey `protobuf:"bytes,3,opt,name=paillier_sk,json=paillierSk,proto3" json:"paillier_sk,omitempty"`
+	NTildeI    []byte                                `protobuf:"bytes,4,opt,name=n_tilde_i,json=nTildeI,proto3" json:"n_tilde_i,omitempty"`
+	H1I        []byte                                `protobuf:"bytes,5,opt,name=h1_i,json=h1I,proto3" json:"h1_i,omitempty"`
+	H2I        []byte                                `protobuf:"bytes,6,opt,name=h2_i,json=h2I,proto3" json:"h2_i,omitempty"`
+	Alpha      []byte                                `protobuf:"bytes,7,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta       []byte                                `protobuf:"bytes,8,opt,name=beta,proto3" json:"beta,omitempty"`
+	P          []byte                                `protobuf:"bytes,9,opt,name=p,proto3" json:"p,omitempty"`
+	Q          []byte                                `protobuf:"bytes,10,opt,name=q,proto3" json:"q,omitempty"`
+	// LocalSecrets
+	Xi          []byte                       `protobuf:"bytes,11,opt,name=xi,proto3" json:"xi,omitempty"`
+	ShareId     []byte                       `protobuf:"bytes,12,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Ks          [][]byte                     `protobuf:"bytes,13,rep,name=ks,proto3" json:"ks,omitempty"`
+	NTildeJ     [][]byte                     `protobuf:"bytes,14,rep,name=n_tilde_j,json=nTildeJ,proto3" json:"n_tilde_j,omitempty"`
+	H1J         [][]byte                     `protobuf:"bytes,15,rep,name=h1_j,json=h1J,proto3" json:"h1_j,omitempty"`
+	H2J         [][]byte                     `protobuf:"bytes,16,rep,name=h2_j,json=h2J,proto3" json:"h2_j,omitempty"`
+	BigXJ       []*PersistedSaveData_ECPoint `protobuf:"bytes,17,rep,name=big_x_j,json=bigXJ,proto3" json:"big_x_j,omitempty"`
+	PaillierPks [][]byte                     `protobuf:"bytes,18,rep,name=paillier_pks,json=paillierPks,proto3" json:"paillier_pks,omitempty"`
+	EcdsaPub    *PersistedSaveData_ECPoint   `protobuf:"bytes,19,opt,name=ecdsa_pub,json=ecdsaPub,proto3" json:"ecdsa_pub,omitempty"`
+	// Which Paillier proofs were verified for each party; empty for records written before this was tracked
+	ProofModVerified []bool `protobuf:"varint,20,rep,packed,name=proof_mod_verified,json=proofModVerified,proto3" json:"proof_mod_verified,omitempty"`
+	ProofFacVerified []bool `protobuf:"varint,21,rep,packed,name=proof_fac_verified,json=proofFacVerified,proto3" json:"proof_fac_verified,omitempty"`
+	// BIP-32 chain code of the key; empty for keys generated before keygen produced one
+	ChainCode []byte `protobuf:"bytes,22,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`
+}
+
+func (x *PersistedSaveData) Reset() {
+	*x = PersistedSaveData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_save_data_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PersistedSaveData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersistedSaveData) ProtoMessage() {}
+
+func (x *PersistedSaveData) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_save_data_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersistedSaveData.ProtoReflect.Descriptor instead.
+func (*PersistedSaveData) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_save_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PersistedSaveData) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PersistedSaveData) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *PersistedSaveData) GetPaillierSk() *PersistedSaveData_PaillierPrivateKey {
+	if x != nil {
+		return x.PaillierSk
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetNTildeI() []byte {
+	if x != nil {
+		return x.NTildeI
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetH1I() []byte {
+	if x != nil {
+		return x.H1I
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetH2I() []byte {
+	if x != nil {
+		return x.H2I
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetAlpha() []byte {
+	if x != nil {
+		return x.Alpha
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetBeta() []byte {
+	if x != nil {
+		return x.Beta
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetP() []byte {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetQ() []byte {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetXi() []byte {
+	if x != nil {
+		return x.Xi
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetShareId() []byte {
+	if x != nil {
+		return x.ShareId
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetNTildeJ() [][]byte {
+	if x != nil {
+		return x.NTildeJ
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetH1J() [][]byte {
+	if x != nil {
+		return x.H1J
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetH2J() [][]byte {
+	if x != nil {
+		return x.H2J
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetBigXJ() []*PersistedSaveData_ECPoint {
+	if x != nil {
+		return x.BigXJ
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetPaillierPks() [][]byte {
+	if x != nil {
+		return x.PaillierPks
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetEcdsaPub() *PersistedSaveData_ECPoint {
+	if x != nil {
+		return x.EcdsaPub
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetProofModVerified() []bool {
+	if x != nil {
+		return x.ProofModVerified
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetProofFacVerified() []bool {
+	if x != nil {
+		return x.ProofFacVerified
+	}
+	return nil
+}
+
+func (x *PersistedSaveData) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+type PersistedSaveData_ECPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X []byte `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y []byte `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *PersistedSaveData_ECPoint) Reset() {
+	*x = PersistedSaveData_ECPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_save_data_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PersistedSaveData_ECPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersistedSaveData_ECPoint) ProtoMessage() {}
+
+func (x *PersistedSaveData_ECPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_save_data_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersistedSaveData_ECPoint.ProtoReflect.Descriptor instead.
+func (*PersistedSaveData_ECPoint) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_save_data_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *PersistedSaveData_ECPoint) GetX() []byte {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *PersistedSaveData_ECPoint) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+type PersistedSaveData_PaillierPrivateKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N       []byte `protobuf:"bytes,1,opt,name=n,proto3" json:"n,omitempty"`
+	LambdaN []byte `protobuf:"bytes,2,opt,name=lambda_n,json=lambdaN,proto3" json:"lambda_n,omitempty"`
+	PhiN    []byte `protobuf:"bytes,3,opt,name=phi_n,json=phiN,proto3" json:"phi_n,omitempty"`
+	P       []byte `protobuf:"bytes,4,opt,name=p,proto3" json:"p,omitempty"`
+	Q       []byte `protobuf:"bytes,5,opt,name=q,proto3" json:"q,omitempty"`
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) Reset() {
+	*x = PersistedSaveData_PaillierPrivateKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_save_data_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersistedSaveData_PaillierPrivateKey) ProtoMessage() {}
+
+func (x *PersistedSaveData_PaillierPrivateKey) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_save_data_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersistedSaveData_PaillierPrivateKey.ProtoReflect.Descriptor instead.
+func (*PersistedSaveData_PaillierPrivateKey) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_save_data_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) GetN() []byte {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) GetLambdaN() []byte {
+	if x != nil {
+		return x.LambdaN
+	}
+	return nil
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) GetPhiN() []byte {
+	if x != nil {
+		return x.PhiN
+	}
+	return nil
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) GetP() []byte {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *PersistedSaveData_PaillierPrivateKey) GetQ() []byte {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+var File_protob_ecdsa_save_data_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_save_data_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
+	0x61, 0x76, 0x65, 0x2d, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b,
+	0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0x86, 0x07, 0x0a, 0x11,
+	0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x75, 0x72, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x75, 0x72, 0x76,
+	0x65, 0x12, 0x62, 0x0a, 0x0b, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x73, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65,
+	0x79, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x53, 0x61,
+	0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x53, 0x6b, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65,
+	0x5f, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65,
+	0x49, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x31, 0x5f, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x68, 0x31, 0x49, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x32, 0x5f, 0x69, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x68, 0x32, 0x49, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a,
+	0x04, 0x62, 0x65, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x65, 0x74,
+	0x61, 0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12,
+	0x0c, 0x0a, 0x01, 0x71, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x12, 0x0e, 0x0a,
+	0x02, 0x78, 0x69, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x78, 0x69, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x73, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f, 0x74, 0x69,
+	0x6c, 0x64, 0x65, 0x5f, 0x6a, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x54, 0x69,
+	0x6c, 0x64, 0x65, 0x4a, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x31, 0x5f, 0x6a, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x03, 0x68, 0x31, 0x4a, 0x12, 0x11, 0x0a, 0x04, 0x68, 0x32, 0x5f, 0x6a, 0x18,
+	0x10, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x68, 0x32, 0x4a, 0x12, 0x4e, 0x0a, 0x07, 0x62, 0x69,
+	0x67, 0x5f, 0x78, 0x5f, 0x6a, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x62, 0x69,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64,
+	0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73,
+	0x74, 0x65, 0x64, 0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x43, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x05, 0x62, 0x69, 0x67, 0x58, 0x4a, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61,
+	0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x6b, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0b, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x50, 0x6b, 0x73, 0x12, 0x53, 0x0a,
+	0x09, 0x65, 0x63, 0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x36, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x50,
+	0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x64, 0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x65, 0x63, 0x64, 0x73, 0x61, 0x50,
+	0x75, 0x62, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6d, 0x6f, 0x64, 0x5f,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x14, 0x20, 0x03, 0x28, 0x08, 0x52, 0x10,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x4d, 0x6f, 0x64, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x12, 0x2c, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x66, 0x61, 0x63, 0x5f, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x15, 0x20, 0x03, 0x28, 0x08, 0x52, 0x10, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x46, 0x61, 0x63, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x25, 0x0a,
+	0x07, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x01, 0x79, 0x1a, 0x6e, 0x0a, 0x12, 0x50, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x6d, 0x62,
+	0x64, 0x61, 0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6c, 0x61, 0x6d, 0x62,
+	0x64, 0x61, 0x4e, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x68, 0x69, 0x5f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x70, 0x68, 0x69, 0x4e, 0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x01, 0x71, 0x42, 0x0e, 0x5a, 0x0c, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x6b, 0x65,
+	0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_save_data_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_save_data_proto_rawDescData = file_protob_ecdsa_save_data_proto_rawDesc
+)
+
+func file_protob_ecdsa_save_data_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_save_data_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_save_data_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_save_data_proto_rawDescData)
+	})
+	return file_protob_ecdsa_save_data_proto_rawDescData
+}
+
+var file_protob_ecdsa_save_data_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protob_ecdsa_save_data_proto_goTypes = []interface{}{
+	(*PersistedSaveData)(nil),                    // 0: binance.tsslib.ecdsa.keygen.PersistedSaveData
+	(*PersistedSaveData_ECPoint)(nil),            // 1: binance.tsslib.ecdsa.keygen.PersistedSaveData.ECPoint
+	(*PersistedSaveData_PaillierPrivateKey)(nil), // 2: binance.tsslib.ecdsa.keygen.PersistedSaveData.PaillierPrivateKey
+}
+var file_protob_ecdsa_save_data_proto_depIdxs = []int32{
+	2, // 0: binance.tsslib.ecdsa.keygen.PersistedSaveData.paillier_sk:type_name -> binance.tsslib.ecdsa.keygen.PersistedSaveData.PaillierPrivateKey
+	1, // 1: binance.tsslib.ecdsa.keygen.PersistedSaveData.big_x_j:type_name -> binance.tsslib.ecdsa.keygen.PersistedSaveData.ECPoint
+	1, // 2: binance.tsslib.ecdsa.keygen.PersistedSaveData.ecdsa_pub:type_name -> binance.tsslib.ecdsa.keygen.PersistedSaveData.ECPoint
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_save_data_proto_init() }
+func file_protob_ecdsa_save_data_proto_init() {
+	if File_protob_ecdsa_save_data_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_save_data_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PersistedSaveData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_save_data_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PersistedSaveData_ECPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_save_data_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PersistedSaveData_PaillierPrivateKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_save_data_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_save_data_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_save_data_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_save_data_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_save_data_proto = out.File
+	file_protob_ecdsa_save_data_proto_rawDesc = nil
+	file_protob_ecdsa_save_data_proto_goTypes = nil
+	file_protob_ecdsa_save_data_proto_depIdxs = nil
+}
@@ -46,16 +46,34 @@ type (
 
 	localTempData struct {
 		localMessageStore
+		keyTempData // the first (or only) key
 
 		// temp data (thrown away after keygen)
+		ssid      []byte
+		ssidNonce *big.Int
+
+		// commitments of the parties to their contributions to the chain code, and the de-commitment of ours
+		chainCodeCmts     []cmt.HashCommitment
+		chainCodeDeCommit cmt.HashDeCommitment
+
+		// batch keygen: the additional keys generated in this session
+		batch    []*batchKey
+		batchEnd chan<- []*LocalPartySaveData
+	}
+
+	// temp data of a single key; a batch keygen generates several keys in one session
+	keyTempData struct {
 		ui            *big.Int // used for tests
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
-		ssid          []byte
-		ssidNonce     *big.Int
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
 	}
+
+	batchKey struct {
+		keyTempData
+		save LocalPartySaveData
+	}
 )
 
 // Exported, used in `tss` client
@@ -92,6 +110,33 @@ func NewLocalParty(
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.chainCodeCmts = make([]cmt.HashCommitment, partyCount)
+	return p
+}
+
+// NewBatchLocalParty creates a party that generates `batchSize` independent keys in a single keygen session.
+// The keys share this party's pre-params, so the Paillier and NTilde proofs are created and verified only once per peer,
+// and the VSS messages of all of the keys are sent together. Every party must use the same batch size.
+// The save data of the keys is sent through `end` once completed, in the same order for every party.
+func NewBatchLocalParty(
+	params *tss.Parameters,
+	batchSize int,
+	out chan<- tss.Message,
+	end chan<- []*LocalPartySaveData,
+	optionalPreParams ...LocalPreParams,
+) tss.Party {
+	if batchSize < 1 {
+		panic(errors.New("keygen.NewBatchLocalParty expected a batch size of at least 1"))
+	}
+	p := NewLocalParty(params, out, nil, optionalPreParams...).(*LocalParty)
+	p.temp.batchEnd = end
+	p.temp.batch = make([]*batchKey, batchSize-1)
+	for k := range p.temp.batch {
+		p.temp.batch[k] = &batchKey{
+			keyTempData: keyTempData{KGCs: make([]cmt.HashCommitment, params.PartyCount())},
+			save:        NewLocalPartySaveData(params.PartyCount()),
+		}
+	}
 	return p
 }
 
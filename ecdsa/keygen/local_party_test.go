@@ -152,7 +152,7 @@ func TestBadMessageCulprits(t *testing.T) {
 		assert.FailNow(t, err.Error())
 	}
 
-	badMsg, _ := NewKGRound1Message(pIDs[1], zero, &paillier.PublicKey{N: zero}, zero, zero, zero, new(dlnproof.Proof), new(dlnproof.Proof))
+	badMsg, _ := NewKGRound1Message(pIDs[1], zero, zero, &paillier.PublicKey{N: zero}, zero, zero, zero, new(dlnproof.Proof), new(dlnproof.Proof), false, false)
 	ok, err2 := lp.Update(badMsg)
 	t.Log(err2)
 	assert.False(t, ok)
@@ -166,6 +166,62 @@ func TestBadMessageCulprits(t *testing.T) {
 		err2.Error())
 }
 
+func TestPreParamsBelowModulusLen(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	assert.Equal(t, tss.DefaultPaillierModulusLen, fixtures[0].LocalPreParams.ModulusLen())
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	assert.NoError(t, params.SetPaillierModulusLen(3072))
+	lp := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil, fixtures[0].LocalPreParams).(*LocalParty)
+	assert.Error(t, lp.Start(), "2048-bit pre-params should not satisfy a 3072-bit security level")
+}
+
+func TestProofPolicyDowngrade(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	out := make(chan tss.Message, len(pIDs)*len(pIDs))
+
+	// P[0] requires the proofs, the other parties skip the modProof
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		if i > 0 {
+			params.SetNoProofMod()
+		}
+		P := NewLocalParty(params, out, nil, fixtures[i].LocalPreParams).(*LocalParty)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		parties = append(parties, P)
+	}
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	for range pIDs {
+		msg := <-out
+		if msg.GetFrom().Index == 0 {
+			continue
+		}
+		assert.True(t, msg.(tss.ParsedMessage).Content().(*KGRound1Message).GetNoProofMod(), "round 1 should advertise the proof policy")
+		test.SharedPartyUpdater(parties[0], msg, errCh)
+	}
+	close(errCh)
+	err2 := <-errCh
+	if !assert.NotNil(t, err2, "a party skipping the modProof should be rejected") {
+		return
+	}
+	assert.Equal(t, []*tss.PartyID{pIDs[1]}, err2.Culprits())
+}
+
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
@@ -338,6 +394,89 @@ keygen:
 	}
 }
 
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+
+	const batchSize = 3
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan []*LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		P := NewBatchLocalParty(params, batchSize, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	batches := make([][]*LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case saves := <-endCh:
+			batches = append(batches, saves)
+			if len(batches) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	for k := 0; k < batchSize; k++ {
+		shares := make(vss.Shares, 0, len(batches))
+		for _, saves := range batches {
+			if !assert.Len(t, saves, batchSize) {
+				return
+			}
+			save := saves[k]
+			assert.NoError(t, save.Validate(threshold))
+			assert.True(t, save.ECDSAPub.Equals(batches[0][k].ECDSAPub), "the parties should agree on each key")
+			assert.Len(t, save.ChainCode, ChainCodeLen)
+			assert.Equal(t, batches[0][k].ChainCode, save.ChainCode, "the parties should agree on each chain code")
+			assert.Equal(t, saves[0].PaillierSK, save.PaillierSK, "the keys should share the pre-params")
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		}
+		x, err := shares[:threshold+1].ReConstruct(tss.S256())
+		assert.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), x).Equals(batches[0][k].ECDSAPub))
+		if k > 0 {
+			assert.False(t, batches[0][k].ECDSAPub.Equals(batches[0][k-1].ECDSAPub), "the keys should be independent")
+			assert.NotEqual(t, batches[0][k].ChainCode, batches[0][k-1].ChainCode, "the chain codes should be independent")
+		}
+	}
+}
+
 func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(index)
 
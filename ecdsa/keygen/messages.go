@@ -37,9 +37,12 @@ var (
 func NewKGRound1Message(
 	from *tss.PartyID,
 	ct cmt.HashCommitment,
+	chainCodeCt cmt.HashCommitment,
 	paillierPK *paillier.PublicKey,
 	nTildeI, h1I, h2I *big.Int,
 	dlnProof1, dlnProof2 *dlnproof.Proof,
+	noProofMod, noProofFac bool,
+	batchCts ...cmt.HashCommitment,
 ) (tss.ParsedMessage, error) {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -61,6 +64,13 @@ func NewKGRound1Message(
 		H2:         h2I.Bytes(),
 		Dlnproof_1: dlnProof1Bz,
 		Dlnproof_2: dlnProof2Bz,
+		NoProofMod: noProofMod,
+		NoProofFac: noProofFac,
+
+		ChainCodeCommitment: chainCodeCt.Bytes(),
+	}
+	for _, batchCt := range batchCts {
+		content.BatchCommitments = append(content.BatchCommitments, batchCt.Bytes())
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
@@ -69,19 +79,30 @@ func NewKGRound1Message(
 func (m *KGRound1Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.GetCommitment()) &&
+		common.NonEmptyBytes(m.GetChainCodeCommitment()) &&
 		common.NonEmptyBytes(m.GetPaillierN()) &&
 		common.NonEmptyBytes(m.GetNTilde()) &&
 		common.NonEmptyBytes(m.GetH1()) &&
 		common.NonEmptyBytes(m.GetH2()) &&
 		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
 		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
-		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2)) &&
+		(len(m.GetBatchCommitments()) == 0 || common.NonEmptyMultiBytes(m.GetBatchCommitments()))
 }
 
 func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 	return new(big.Int).SetBytes(m.GetCommitment())
 }
 
+func (m *KGRound1Message) UnmarshalChainCodeCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetChainCodeCommitment())
+}
+
+// UnmarshalBatchCommitments returns the commitments of the additional keys of a batch keygen
+func (m *KGRound1Message) UnmarshalBatchCommitments() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBatchCommitments())
+}
+
 func (m *KGRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
 	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
 }
@@ -112,6 +133,7 @@ func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
 	proof *facproof.ProofFac,
+	batchShares ...*vss.Share,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -123,13 +145,17 @@ func NewKGRound2Message1(
 		Share:    share.Share.Bytes(),
 		FacProof: proofBzs[:],
 	}
+	for _, batchShare := range batchShares {
+		content.BatchShares = append(content.BatchShares, batchShare.Share.Bytes())
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message1) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.GetShare())
+		common.NonEmptyBytes(m.GetShare()) &&
+		(len(m.GetBatchShares()) == 0 || common.NonEmptyMultiBytes(m.GetBatchShares()))
 	// This is commented for backward compatibility, which msg has no proof
 	// && common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts)
 }
@@ -138,6 +164,11 @@ func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 	return new(big.Int).SetBytes(m.Share)
 }
 
+// UnmarshalBatchShares returns the shares of the additional keys of a batch keygen
+func (m *KGRound2Message1) UnmarshalBatchShares() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBatchShares())
+}
+
 func (m *KGRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
 	return facproof.NewProofFromBytes(m.GetFacProof())
 }
@@ -147,7 +178,9 @@ func (m *KGRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	chainCodeDeCommitment cmt.HashDeCommitment,
 	proof *modproof.ProofMod,
+	batchDeCommitments ...cmt.HashDeCommitment,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -158,16 +191,30 @@ func NewKGRound2Message2(
 	content := &KGRound2Message2{
 		DeCommitment: dcBzs,
 		ModProof:     proofBzs[:],
+
+		ChainCodeDeCommitment: common.BigIntsToBytes(chainCodeDeCommitment),
+	}
+	for _, batchDeCommitment := range batchDeCommitments {
+		content.Batch = append(content.Batch, &KGRound2Message2_BatchKey{
+			DeCommitment: common.BigIntsToBytes(batchDeCommitment),
+		})
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message2) ValidateBasic() bool {
-	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+	if m == nil || !common.NonEmptyMultiBytes(m.GetDeCommitment()) || !common.NonEmptyMultiBytes(m.GetChainCodeDeCommitment()) {
+		return false
+	}
+	for _, key := range m.GetBatch() {
+		if !common.NonEmptyMultiBytes(key.GetDeCommitment()) {
+			return false
+		}
+	}
 	// This is commented for backward compatibility, which msg has no proof
 	// && common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts)
+	return true
 }
 
 func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
@@ -175,6 +222,20 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+func (m *KGRound2Message2) UnmarshalChainCodeDeCommitment() cmt.HashDeCommitment {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetChainCodeDeCommitment())
+}
+
+// UnmarshalBatchDeCommitments returns the de-commitments of the additional keys of a batch keygen
+func (m *KGRound2Message2) UnmarshalBatchDeCommitments() []cmt.HashDeCommitment {
+	batch := m.GetBatch()
+	deComs := make([]cmt.HashDeCommitment, len(batch))
+	for k, key := range batch {
+		deComs[k] = cmt.NewHashDeCommitmentFromBytes(key.GetDeCommitment())
+	}
+	return deComs
+}
+
 func (m *KGRound2Message2) UnmarshalModProof() (*modproof.ProofMod, error) {
 	return modproof.NewProofFromBytes(m.GetModProof())
 }
@@ -8,28 +8,63 @@ package keygen
 
 import (
 	"context"
-	"crypto/rand"
+	cryptorand "crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 const (
-	// Using a modulus length of 2048 is recommended in the GG18 spec
-	paillierModulusLen = 2048
-	// Two 1024-bit safe primes to produce NTilde
-	safePrimeBitLen = 1024
 	// Ticker for printing log statements while generating primes/modulus
 	logProgressTickInterval = 8 * time.Second
 	// Safe big len using random for ssid
 	SafeBitLen = 1024
 )
 
+type (
+	// PreParamsOptions configures GeneratePreParamsWithOptions; the zero value generates default pre-params
+	PreParamsOptions struct {
+		// bit length of the Paillier modulus and NTilde, tss.DefaultPaillierModulusLen if 0
+		ModulusLen int
+		// the number of available CPU cores if 0
+		Concurrency int
+		// crypto/rand.Reader if nil
+		Rand io.Reader
+		// if set, the generation resumes from the safe primes held in it and records every new safe prime found
+		Partial *PartialPreParams
+		// if set, called with the progress when the generation starts, whenever a safe prime is found and periodically
+		Progress func(PreParamsProgress)
+	}
+
+	// PreParamsProgress is a snapshot of the progress of GeneratePreParamsWithOptions
+	PreParamsProgress struct {
+		// safe prime candidates that reached the final primality test, in this call only
+		CandidatesTested uint64
+		// safe primes found so far, out of the two needed for each of the Paillier modulus and NTilde
+		PaillierPrimesFound, NTildePrimesFound int
+	}
+
+	// PartialPreParams holds the safe primes p = 2q+1 found by an interrupted pre-params generation.
+	// It may be persisted (e.g. as JSON) and passed back in PreParamsOptions to resume the generation.
+	// Safe primes generated elsewhere may be supplied in it too; they are validated before use.
+	PartialPreParams struct {
+		ModulusLen     int
+		PaillierPrimes []*big.Int
+		NTildePrimes   []*big.Int
+
+		mtx sync.Mutex
+	}
+)
+
 // GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
@@ -45,7 +80,7 @@ func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*Loca
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
 // If pre-parameters could not be generated before the context is done, an error is returned.
 func GeneratePreParamsWithContext(ctx context.Context, optionalConcurrency ...int) (*LocalPreParams, error) {
-	return GeneratePreParamsWithContextAndRandom(ctx, rand.Reader, optionalConcurrency...)
+	return GeneratePreParamsWithContextAndRandom(ctx, cryptorand.Reader, optionalConcurrency...)
 }
 
 // GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
@@ -53,82 +88,164 @@ func GeneratePreParamsWithContext(ctx context.Context, optionalConcurrency ...in
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
 // If pre-parameters could not be generated before the context is done, an error is returned.
 func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader, optionalConcurrency ...int) (*LocalPreParams, error) {
+	return GeneratePreParamsWithModulusLen(ctx, rand, tss.DefaultPaillierModulusLen, optionalConcurrency...)
+}
+
+// GeneratePreParamsWithModulusLen generates pre-parameters with a Paillier modulus and NTilde of `modulusLen` bits,
+// which must match the tss.Parameters.PaillierModulusLen() that the keygen or re-sharing party is created with.
+// NTilde is the product of two safe primes of `modulusLen/2` bits.
+// If not specified, a concurrency value equal to the number of available CPU cores will be used.
+// If pre-parameters could not be generated before the context is done, an error is returned.
+func GeneratePreParamsWithModulusLen(ctx context.Context, rand io.Reader, modulusLen int, optionalConcurrency ...int) (*LocalPreParams, error) {
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
 			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
 		}
 		concurrency = optionalConcurrency[0]
-	} else {
+	}
+	return GeneratePreParamsWithOptions(ctx, PreParamsOptions{
+		ModulusLen:  modulusLen,
+		Concurrency: concurrency,
+		Rand:        rand,
+	})
+}
+
+// GeneratePreParamsWithOptions generates pre-parameters like GeneratePreParamsWithModulusLen and additionally supports
+// progress reporting, resuming an interrupted generation and supplying safe primes generated elsewhere.
+// If pre-parameters could not be generated before the context is done, an error is returned and `opts.Partial`,
+// if set, holds the safe primes found so far so that they may be persisted and passed back in a later call.
+func GeneratePreParamsWithOptions(ctx context.Context, opts PreParamsOptions) (*LocalPreParams, error) {
+	modulusLen := opts.ModulusLen
+	if modulusLen == 0 {
+		modulusLen = tss.DefaultPaillierModulusLen
+	}
+	if err := checkModulusLen(modulusLen); err != nil {
+		return nil, err
+	}
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
 		concurrency = runtime.NumCPU()
 	}
 	if concurrency /= 3; concurrency < 1 {
 		concurrency = 1
 	}
+	rand := opts.Rand
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	partial := opts.Partial
+	if partial == nil {
+		partial = new(PartialPreParams)
+	}
+	if err := partial.init(modulusLen); err != nil {
+		return nil, err
+	}
+
+	// the searches stop early if the other one fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	// prepare for concurrent Paillier and safe prime generation
-	paiCh := make(chan *paillier.PrivateKey, 1)
-	sgpCh := make(chan []*common.GermainSafePrime, 1)
+	var tested uint64
+	foundCh := make(chan struct{}, 4)
+	search := func(primes *[]*big.Int, concurrency int, accept func(have []*big.Int, p *big.Int) bool) error {
+		for partial.count(primes) < 2 {
+			sgps, err := common.GetRandomSafePrimesConcurrentWithProgress(ctx, modulusLen/2, 1, concurrency, rand, &tested)
+			if err != nil {
+				return err
+			}
+			if partial.add(primes, sgps[0].SafePrime(), accept) {
+				foundCh <- struct{}{}
+			}
+		}
+		return nil
+	}
+	paiCh := make(chan error, 1)
+	sgpCh := make(chan error, 1)
 
 	// 4. generate Paillier public key E_i, private key and proof
-	go func(ch chan<- *paillier.PrivateKey) {
+	go func(ch chan<- error) {
 		common.Logger.Info("generating the Paillier modulus, please wait...")
 		start := time.Now()
 		// more concurrency weight is assigned here because the paillier primes have a requirement of having "large" P-Q
-		PiPaillierSk, _, err := paillier.GenerateKeyPair(ctx, rand, paillierModulusLen, concurrency*2)
-		if err != nil {
-			ch <- nil
-			return
+		err := search(&partial.PaillierPrimes, concurrency*2, func(have []*big.Int, P *big.Int) bool {
+			if len(have) == 0 {
+				return true
+			}
+			_, err := paillier.NewPrivateKeyFromSafePrimes(have[0], P)
+			return err == nil
+		})
+		if err == nil {
+			common.Logger.Infof("paillier modulus generated. took %s\n", time.Since(start))
 		}
-		common.Logger.Infof("paillier modulus generated. took %s\n", time.Since(start))
-		ch <- PiPaillierSk
+		ch <- err
 	}(paiCh)
 
 	// 5-7. generate safe primes for ZKPs used later on
-	go func(ch chan<- []*common.GermainSafePrime) {
-		var err error
+	go func(ch chan<- error) {
 		common.Logger.Info("generating the safe primes for the signing proofs, please wait...")
 		start := time.Now()
-		sgps, err := common.GetRandomSafePrimesConcurrent(ctx, safePrimeBitLen, 2, concurrency, rand)
-		if err != nil {
-			ch <- nil
-			return
+		err := search(&partial.NTildePrimes, concurrency, func(have []*big.Int, P *big.Int) bool {
+			return len(have) == 0 || have[0].Cmp(P) != 0
+		})
+		if err == nil {
+			common.Logger.Infof("safe primes generated. took %s\n", time.Since(start))
 		}
-		common.Logger.Infof("safe primes generated. took %s\n", time.Since(start))
-		ch <- sgps
+		ch <- err
 	}(sgpCh)
 
+	progress := func() {
+		if opts.Progress != nil {
+			opts.Progress(PreParamsProgress{
+				CandidatesTested:    atomic.LoadUint64(&tested),
+				PaillierPrimesFound: partial.count(&partial.PaillierPrimes),
+				NTildePrimesFound:   partial.count(&partial.NTildePrimes),
+			})
+		}
+	}
+	progress()
+
 	// this ticker will print a log statement while the generating is still in progress
 	logProgressTicker := time.NewTicker(logProgressTickInterval)
+	defer logProgressTicker.Stop()
 
-	// errors can be thrown in the following code; consume chans to end goroutines here
-	var sgps []*common.GermainSafePrime
-	var paiSK *paillier.PrivateKey
-consumer:
-	for {
+	// both searches are always waited for, so that `opts.Partial` is not written to after returning
+	var paiErr, sgpErr error
+	for pending := 2; pending > 0; {
 		select {
 		case <-logProgressTicker.C:
 			common.Logger.Info("still generating primes...")
-		case sgps = <-sgpCh:
-			if sgps == nil ||
-				sgps[0] == nil || sgps[1] == nil ||
-				!sgps[0].Prime().ProbablyPrime(30) || !sgps[1].Prime().ProbablyPrime(30) ||
-				!sgps[0].SafePrime().ProbablyPrime(30) || !sgps[1].SafePrime().ProbablyPrime(30) {
-				return nil, errors.New("timeout or error while generating the safe primes")
-			}
-			if paiSK != nil {
-				break consumer
+			progress()
+		case <-foundCh:
+			progress()
+		case paiErr = <-paiCh:
+			if pending--; paiErr != nil {
+				cancel()
 			}
-		case paiSK = <-paiCh:
-			if paiSK == nil {
-				return nil, errors.New("timeout or error while generating the Paillier secret key")
-			}
-			if sgps != nil {
-				break consumer
+		case sgpErr = <-sgpCh:
+			if pending--; sgpErr != nil {
+				cancel()
 			}
 		}
 	}
-	logProgressTicker.Stop()
+	if sgpErr != nil {
+		return nil, errors.New("timeout or error while generating the safe primes")
+	}
+	if paiErr != nil {
+		return nil, errors.New("timeout or error while generating the Paillier secret key")
+	}
+
+	paiSK, err := paillier.NewPrivateKeyFromSafePrimes(partial.PaillierPrimes[0], partial.PaillierPrimes[1])
+	if err != nil {
+		return nil, err
+	}
+	sgps := make([]*common.GermainSafePrime, 2)
+	for i, P := range partial.NTildePrimes {
+		if sgps[i], err = common.NewGermainSafePrime(P); err != nil {
+			return nil, err
+		}
+	}
 
 	P, Q := sgps[0].SafePrime(), sgps[1].SafePrime()
 	NTildei := new(big.Int).Mul(P, Q)
@@ -154,3 +271,59 @@ consumer:
 	}
 	return preParams, nil
 }
+
+// ----- //
+
+// init checks the safe primes of a resumed or externally supplied PartialPreParams
+func (partial *PartialPreParams) init(modulusLen int) error {
+	partial.mtx.Lock()
+	defer partial.mtx.Unlock()
+	if partial.ModulusLen == 0 {
+		partial.ModulusLen = modulusLen
+	} else if partial.ModulusLen != modulusLen {
+		return fmt.Errorf("the partial pre-params were generated for a %d-bit modulus, not %d", partial.ModulusLen, modulusLen)
+	}
+	if len(partial.PaillierPrimes) > 2 || len(partial.NTildePrimes) > 2 {
+		return errors.New("the partial pre-params hold more than two safe primes for the Paillier modulus or NTilde")
+	}
+	for _, P := range append(append([]*big.Int{}, partial.PaillierPrimes...), partial.NTildePrimes...) {
+		if P == nil || P.BitLen() != modulusLen/2 {
+			return fmt.Errorf("the partial pre-params hold a safe prime that is not %d bits", modulusLen/2)
+		}
+		if _, err := common.NewGermainSafePrime(P); err != nil {
+			return fmt.Errorf("the partial pre-params hold an invalid safe prime: %v", err)
+		}
+	}
+	if len(partial.PaillierPrimes) == 2 {
+		if _, err := paillier.NewPrivateKeyFromSafePrimes(partial.PaillierPrimes[0], partial.PaillierPrimes[1]); err != nil {
+			return fmt.Errorf("the partial pre-params hold unsuitable Paillier primes: %v", err)
+		}
+	}
+	if len(partial.NTildePrimes) == 2 && partial.NTildePrimes[0].Cmp(partial.NTildePrimes[1]) == 0 {
+		return errors.New("the partial pre-params hold the same NTilde safe prime twice")
+	}
+	return nil
+}
+
+func (partial *PartialPreParams) count(primes *[]*big.Int) int {
+	partial.mtx.Lock()
+	defer partial.mtx.Unlock()
+	return len(*primes)
+}
+
+func (partial *PartialPreParams) add(primes *[]*big.Int, P *big.Int, accept func(have []*big.Int, P *big.Int) bool) bool {
+	partial.mtx.Lock()
+	defer partial.mtx.Unlock()
+	if !accept(*primes, P) {
+		return false
+	}
+	*primes = append(*primes, P)
+	return true
+}
+
+func checkModulusLen(modulusLen int) error {
+	if modulusLen < tss.DefaultPaillierModulusLen || modulusLen%2 != 0 {
+		return fmt.Errorf("the Paillier modulus length must be even and at least %d bits, got %d", tss.DefaultPaillierModulusLen, modulusLen)
+	}
+	return nil
+}
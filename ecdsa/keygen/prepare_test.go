@@ -8,6 +8,9 @@ package keygen
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
 	"testing"
 	"time"
 
@@ -51,3 +54,68 @@ func TestGenerateWithContext(t *testing.T) {
 	assert.NotNil(t, preParams.P)
 	assert.NotNil(t, preParams.Q)
 }
+
+func TestGeneratePreParamsWithModulusLenTooShort(t *testing.T) {
+	preParams, err := GeneratePreParamsWithModulusLen(context.Background(), rand.Reader, 1024, 1)
+	assert.Nil(t, preParams)
+	assert.Error(t, err)
+}
+
+func TestGeneratePreParamsWithSuppliedSafePrimes(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	fixture := fixtures[0].LocalPreParams
+	safePrime := func(q *big.Int) *big.Int {
+		return new(big.Int).Add(new(big.Int).Lsh(q, 1), big.NewInt(1))
+	}
+	partial := &PartialPreParams{
+		PaillierPrimes: []*big.Int{fixture.PaillierSK.P, fixture.PaillierSK.Q},
+		NTildePrimes:   []*big.Int{safePrime(fixture.P), safePrime(fixture.Q)},
+	}
+	var progress []PreParamsProgress
+	preParams, err := GeneratePreParamsWithOptions(context.Background(), PreParamsOptions{
+		Partial:  partial,
+		Progress: func(p PreParamsProgress) { progress = append(progress, p) },
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, preParams.ValidateWithProof())
+	assert.Equal(t, fixture.PaillierSK.N, preParams.PaillierSK.N)
+	assert.Equal(t, fixture.NTildei, preParams.NTildei)
+	if assert.NotEmpty(t, progress) {
+		assert.Equal(t, 2, progress[0].PaillierPrimesFound)
+		assert.Equal(t, 2, progress[0].NTildePrimesFound)
+	}
+}
+
+func TestGeneratePreParamsResume(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	partial := &PartialPreParams{PaillierPrimes: []*big.Int{fixtures[0].PaillierSK.P}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	preParams, err := GeneratePreParamsWithOptions(ctx, PreParamsOptions{Partial: partial, Concurrency: 1})
+	assert.Nil(t, preParams)
+	assert.Error(t, err)
+
+	// the partial results survive the timeout and can be persisted
+	bz, err := json.Marshal(partial)
+	assert.NoError(t, err)
+	resumed := new(PartialPreParams)
+	assert.NoError(t, json.Unmarshal(bz, resumed))
+	assert.Equal(t, 2048, resumed.ModulusLen)
+	assert.Equal(t, fixtures[0].PaillierSK.P, resumed.PaillierPrimes[0])
+}
+
+func TestGeneratePreParamsRejectsBadSafePrime(t *testing.T) {
+	partial := &PartialPreParams{NTildePrimes: []*big.Int{new(big.Int).Lsh(big.NewInt(1), 1023)}}
+	preParams, err := GeneratePreParamsWithOptions(context.Background(), PreParamsOptions{Partial: partial})
+	assert.Nil(t, preParams)
+	assert.Error(t, err)
+}
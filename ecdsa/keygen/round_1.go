@@ -9,6 +9,7 @@ package keygen
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -39,29 +40,59 @@ func (round *round1) Start() *tss.Error {
 	Pi := round.PartyID()
 	i := Pi.Index
 
-	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.EC().Params().N)
-
-	round.temp.ui = ui
-
-	// 2. compute the vss shares
-	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
-	if err != nil {
+	ids := round.ShareIndexes()
+	if len(ids) != round.PartyCount() {
+		return round.WrapError(fmt.Errorf("got %d share indexes for %d parties", len(ids), round.PartyCount()), Pi)
+	}
+	if _, err := vss.CheckIndexes(round.EC(), ids); err != nil {
 		return round.WrapError(err, Pi)
 	}
-	round.save.Ks = ids
 
-	// security: the original u_i may be discarded
-	ui = zero // clears the secret data from memory
-	_ = ui    // silences a linter warning
+	// the VSS steps run once for each key of a batch keygen
+	temps, saves := round.keys()
+	cmtCs := make([]cmts.HashCommitment, len(temps))
+	for k, temp := range temps {
+		// 1. calculate "partial" key share ui
+		ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.EC().Params().N)
 
-	// make commitment -> (C, D)
-	pGFlat, err := crypto.FlattenECPoints(vs)
-	if err != nil {
-		return round.WrapError(err, Pi)
+		temp.ui = ui
+
+		// 2. compute the vss shares
+		vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		saves[k].Ks = ids
+
+		// security: the original u_i may be discarded
+		ui = zero // clears the secret data from memory
+		_ = ui    // silences a linter warning
+
+		// make commitment -> (C, D)
+		pGFlat, err := crypto.FlattenECPoints(vs)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+		// for this P: SAVE
+		// - shareID
+		// and keep in temporary storage:
+		// - VSS Vs
+		// - our set of Shamir shares
+		// - de-commitment for round 2
+		saves[k].ShareID = ids[i]
+		temp.vs = vs
+		temp.shares = shares
+		temp.deCommitPolyG = cmt.D
+		cmtCs[k] = cmt.C
 	}
-	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+	// commit to a random contribution to the BIP-32 chain code, so that no party can choose its contribution after
+	// seeing the others
+	chainCodeCmt := cmts.NewHashCommitment(round.Rand(), common.MustGetRandomInt(round.Rand(), 8*ChainCodeLen))
+	round.temp.chainCodeCmts[i] = chainCodeCmt.C
+	round.temp.chainCodeDeCommit = chainCodeCmt.D
 
 	// 4. generate Paillier public key E_i, private key and proof
 	// 5-7. generate safe primes for ZKPs used later on
@@ -72,14 +103,19 @@ func (round *round1) Start() *tss.Error {
 		return round.WrapError(
 			errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
 	} else if round.save.LocalPreParams.ValidateWithProof() {
+		if round.save.LocalPreParams.ModulusLen() < round.PaillierModulusLen() {
+			return round.WrapError(fmt.Errorf("`optionalPreParams` are shorter than the required Paillier modulus length of %d bits",
+				round.PaillierModulusLen()), Pi)
+		}
 		preParams = &round.save.LocalPreParams
 	} else {
 		{
 			ctx, cancel := context.WithTimeout(context.Background(), round.SafePrimeGenTimeout())
 			defer cancel()
-			preParams, err = GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+			var err error
+			preParams, err = GeneratePreParamsWithModulusLen(ctx, round.Rand(), round.PaillierModulusLen(), round.Concurrency())
 			if err != nil {
-				return round.WrapError(errors.New("pre-params generation failed"), Pi)
+				return round.WrapError(fmt.Errorf("pre-params generation failed: %v", err), Pi)
 			}
 		}
 	}
@@ -98,30 +134,22 @@ func (round *round1) Start() *tss.Error {
 	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei, round.Rand())
 	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei, round.Rand())
 
-	// for this P: SAVE
-	// - shareID
-	// and keep in temporary storage:
-	// - VSS Vs
-	// - our set of Shamir shares
 	round.temp.ssidNonce = new(big.Int).SetUint64(0)
-	round.save.ShareID = ids[i]
-	round.temp.vs = vs
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(errors.New("failed to generate ssid"))
 	}
 	round.temp.ssid = ssid
-	round.temp.shares = shares
 
-	// for this P: SAVE de-commitments, paillier keys for round 2
+	// for this P: SAVE paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
 	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
-	round.temp.deCommitPolyG = cmt.D
 
 	// BROADCAST commitments, paillier pk + proof; round 1 message
 	{
 		msg, err := NewKGRound1Message(
-			round.PartyID(), cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+			round.PartyID(), cmtCs[0], chainCodeCmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2,
+			round.NoProofMod(), round.NoProofFac(), cmtCs[1:]...)
 		if err != nil {
 			return round.WrapError(err, Pi)
 		}
@@ -9,20 +9,19 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
-const (
-	paillierBitsLen = 2048
-)
-
 func (round *round2) Start() *tss.Error {
 	if round.started {
 		return round.WrapError(errors.New("round already started"))
@@ -51,13 +50,24 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalH2(),
 			r1msg.UnmarshalNTilde(),
 			r1msg.UnmarshalPaillierPK()
-		if paillierPKj.N.BitLen() != paillierBitsLen {
+		// a party may only skip the Paillier proofs if this party's policy allows it too
+		if r1msg.GetNoProofMod() && !round.NoProofMod() {
+			return round.WrapError(errors.New("this party will not send the modProof required by our proof policy"), msg.GetFrom())
+		}
+		if r1msg.GetNoProofFac() && !round.NoProofFac() {
+			return round.WrapError(errors.New("this party will not send the facProof required by our proof policy"), msg.GetFrom())
+		}
+		if len(r1msg.GetBatchCommitments()) != len(round.temp.batch) {
+			return round.WrapError(fmt.Errorf("expected commitments to %d keys but got %d",
+				1+len(round.temp.batch), 1+len(r1msg.GetBatchCommitments())), msg.GetFrom())
+		}
+		if paillierPKj.N.BitLen() < round.PaillierModulusLen() {
 			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
 		}
 		if H1j.Cmp(H2j) == 0 {
 			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
 		}
-		if NTildej.BitLen() != paillierBitsLen {
+		if NTildej.BitLen() < round.PaillierModulusLen() {
 			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
@@ -107,6 +117,10 @@ func (round *round2) Start() *tss.Error {
 		round.save.NTildej[j] = NTildej
 		round.save.H1j[j], round.save.H2j[j] = H1j, H2j
 		round.temp.KGCs[j] = KGC
+		round.temp.chainCodeCmts[j] = r1msg.UnmarshalChainCodeCommitment()
+		for k, KGCk := range r1msg.UnmarshalBatchCommitments() {
+			round.temp.batch[k].KGCs[j] = KGCk
+		}
 	}
 
 	// 5. p2p send share ij to Pj
@@ -127,7 +141,11 @@ func (round *round2) Start() *tss.Error {
 			}
 
 		}
-		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], facProof)
+		batchShares := make([]*vss.Share, len(round.temp.batch))
+		for k, key := range round.temp.batch {
+			batchShares[k] = key.shares[j]
+		}
+		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], facProof, batchShares...)
 		// do not send to this Pj, but store for round 3
 		if j == i {
 			round.temp.kgRound2Message1s[j] = r2msg1
@@ -146,7 +164,11 @@ func (round *round2) Start() *tss.Error {
 			return round.WrapError(err, round.PartyID())
 		}
 	}
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
+	batchDeCommitments := make([]cmt.HashDeCommitment, len(round.temp.batch))
+	for k, key := range round.temp.batch {
+		batchDeCommitments[k] = key.deCommitPolyG
+	}
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, round.temp.chainCodeDeCommit, modProof, batchDeCommitments...)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
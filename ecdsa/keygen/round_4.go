@@ -73,6 +73,20 @@ func (round *round4) Start() *tss.Error {
 		return round.WrapError(errors.New("paillier verify failed"), culprits...)
 	}
 
+	if round.temp.batchEnd != nil {
+		_, saves := round.keys()
+		for _, save := range saves[1:] {
+			save.LocalPreParams = round.save.LocalPreParams
+			copy(save.NTildej, round.save.NTildej)
+			copy(save.H1j, round.save.H1j)
+			copy(save.H2j, round.save.H2j)
+			copy(save.PaillierPKs, round.save.PaillierPKs)
+			copy(save.ProofModVerified, round.save.ProofModVerified)
+			copy(save.ProofFacVerified, round.save.ProofFacVerified)
+		}
+		round.temp.batchEnd <- saves
+		return nil
+	}
 	round.end <- round.save
 
 	return nil
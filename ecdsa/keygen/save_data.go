@@ -9,13 +9,19 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
+// ChainCodeLen is the byte length of a BIP-32 chain code
+const ChainCodeLen = 32
+
 type (
 	LocalPreParams struct {
 		PaillierSK *paillier.PrivateKey // ski
@@ -47,6 +53,14 @@ type (
 
 		// used for test assertions (may be discarded)
 		ECDSAPub *crypto.ECPoint // y
+
+		// BIP-32 chain code generated jointly with the key, to derive child keys with. nil for keys generated before
+		// keygen produced one
+		ChainCode []byte
+
+		// whether the Paillier modulus and factorization proofs of each Pj were verified by this party.
+		// nil in save data produced before this was tracked
+		ProofModVerified, ProofFacVerified []bool
 	}
 )
 
@@ -56,6 +70,7 @@ func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	saveData.H1j, saveData.H2j = make([]*big.Int, partyCount), make([]*big.Int, partyCount)
 	saveData.BigXj = make([]*crypto.ECPoint, partyCount)
 	saveData.PaillierPKs = make([]*paillier.PublicKey, partyCount)
+	saveData.ProofModVerified, saveData.ProofFacVerified = make([]bool, partyCount), make([]bool, partyCount)
 	return
 }
 
@@ -66,6 +81,18 @@ func (preParams LocalPreParams) Validate() bool {
 		preParams.H2i != nil
 }
 
+// ModulusLen returns the security level of the pre-params: the smaller of the bit lengths of the Paillier modulus and NTilde
+func (preParams LocalPreParams) ModulusLen() int {
+	if !preParams.Validate() || preParams.PaillierSK.N == nil {
+		return 0
+	}
+	n, nTilde := preParams.PaillierSK.N.BitLen(), preParams.NTildei.BitLen()
+	if nTilde < n {
+		return nTilde
+	}
+	return n
+}
+
 func (preParams LocalPreParams) ValidateWithProof() bool {
 	return preParams.Validate() &&
 		preParams.PaillierSK.P != nil &&
@@ -76,18 +103,104 @@ func (preParams LocalPreParams) ValidateWithProof() bool {
 		preParams.Q != nil
 }
 
+// Validate checks that the save data is self-consistent for a key shared with the given threshold.
+// The Ks must be distinct and non-zero, Xi*G must match this party's entry in BigXj, the BigXj must interpolate to ECDSAPub
+// and the Paillier modulus and NTilde of every party must have at least tss.DefaultPaillierModulusLen bits.
+func (save LocalPartySaveData) Validate(threshold int) error {
+	if save.ECDSAPub == nil || !save.ECDSAPub.ValidateBasic() {
+		return errors.New("Validate: ECDSAPub is missing or not on the curve")
+	}
+	ec := save.ECDSAPub.Curve()
+	partyCount := len(save.Ks)
+	if len(save.NTildej) != partyCount || len(save.H1j) != partyCount || len(save.H2j) != partyCount ||
+		len(save.BigXj) != partyCount || len(save.PaillierPKs) != partyCount {
+		return errors.New("Validate: the per-party slices in the save data have different lengths")
+	}
+	if (save.ProofModVerified != nil && len(save.ProofModVerified) != partyCount) ||
+		(save.ProofFacVerified != nil && len(save.ProofFacVerified) != partyCount) {
+		return errors.New("Validate: the proof records in the save data have an unexpected length")
+	}
+	if save.ChainCode != nil && len(save.ChainCode) != ChainCodeLen {
+		return fmt.Errorf("Validate: the chain code must be %d bytes", ChainCodeLen)
+	}
+	if threshold < 0 || partyCount <= threshold {
+		return fmt.Errorf("Validate: invalid threshold %d for a key shared by %d parties", threshold, partyCount)
+	}
+	if save.Xi == nil || save.ShareID == nil {
+		return errors.New("Validate: Xi or ShareID is missing")
+	}
+	i := -1
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return fmt.Errorf("Validate: Ks[%d] is missing", j)
+		}
+		if kj.Cmp(save.ShareID) == 0 {
+			i = j
+		}
+	}
+	if i < 0 {
+		return errors.New("Validate: ShareID was not found in Ks")
+	}
+	if _, err := vss.CheckIndexes(ec, save.Ks); err != nil {
+		return fmt.Errorf("Validate: Ks: %v", err)
+	}
+	for j, Xj := range save.BigXj {
+		if Xj == nil || !Xj.SetCurve(ec).ValidateBasic() {
+			return fmt.Errorf("Validate: BigXj[%d] is missing or not on the curve", j)
+		}
+	}
+	if !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		return fmt.Errorf("Validate: Xi*G does not match BigXj[%d]", i)
+	}
+	if err := vss.CheckPublicShares(ec, threshold, save.Ks, save.BigXj, save.ECDSAPub); err != nil {
+		return fmt.Errorf("Validate: %v", err)
+	}
+
+	if !save.LocalPreParams.Validate() {
+		return errors.New("Validate: the Paillier or NTilde pre-params are missing")
+	}
+	one := big.NewInt(1)
+	for j := 0; j < partyCount; j++ {
+		if pk := save.PaillierPKs[j]; pk == nil || pk.N == nil || pk.N.BitLen() < tss.DefaultPaillierModulusLen {
+			return fmt.Errorf("Validate: PaillierPKs[%d] is missing or shorter than %d bits", j, tss.DefaultPaillierModulusLen)
+		}
+		NTildej, h1j, h2j := save.NTildej[j], save.H1j[j], save.H2j[j]
+		if NTildej == nil || NTildej.BitLen() < tss.DefaultPaillierModulusLen {
+			return fmt.Errorf("Validate: NTildej[%d] is missing or shorter than %d bits", j, tss.DefaultPaillierModulusLen)
+		}
+		if h1j == nil || h2j == nil || h1j.Cmp(h2j) == 0 ||
+			h1j.Cmp(one) <= 0 || h2j.Cmp(one) <= 0 || !common.IsInInterval(h1j, NTildej) || !common.IsInInterval(h2j, NTildej) {
+			return fmt.Errorf("Validate: H1j[%d] and H2j[%d] are missing or invalid", j, j)
+		}
+	}
+	if save.PaillierSK.N.Cmp(save.PaillierPKs[i].N) != 0 {
+		return fmt.Errorf("Validate: the Paillier secret key does not match PaillierPKs[%d]", i)
+	}
+	if save.NTildei.Cmp(save.NTildej[i]) != 0 || save.H1i.Cmp(save.H1j[i]) != 0 || save.H2i.Cmp(save.H2j[i]) != 0 {
+		return fmt.Errorf("Validate: NTildei, H1i or H2i does not match NTildej[%d], H1j[%d] or H2j[%d]", i, i, i)
+	}
+	return nil
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
+	return BuildLocalSaveDataSubsetWithIndexes(sourceData, sortedIDs.Keys())
+}
+
+// BuildLocalSaveDataSubsetWithIndexes re-creates the LocalPartySaveData to contain data for only the shares with the
+// given indexes, in that order. Use it when the shares were created with explicit indexes, see `tss.Parameters.SetShareIndexes`.
+func BuildLocalSaveDataSubsetWithIndexes(sourceData LocalPartySaveData, indexes []*big.Int) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
 	for j, kj := range sourceData.Ks {
 		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
 	}
-	newData := NewLocalPartySaveData(sortedIDs.Len())
+	newData := NewLocalPartySaveData(len(indexes))
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
-	for j, id := range sortedIDs {
-		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+	newData.ChainCode = sourceData.ChainCode
+	for j, kj := range indexes {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(kj.Bytes())]
 		if !ok {
 			panic(errors.New("BuildLocalSaveDataSubset: unable to find a signer party in the local save data"))
 		}
@@ -97,6 +210,18 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 		newData.H2j[j] = sourceData.H2j[savedIdx]
 		newData.BigXj[j] = sourceData.BigXj[savedIdx]
 		newData.PaillierPKs[j] = sourceData.PaillierPKs[savedIdx]
+		if sourceData.ProofModVerified != nil {
+			newData.ProofModVerified[j] = sourceData.ProofModVerified[savedIdx]
+		}
+		if sourceData.ProofFacVerified != nil {
+			newData.ProofFacVerified[j] = sourceData.ProofFacVerified[savedIdx]
+		}
+	}
+	if sourceData.ProofModVerified == nil {
+		newData.ProofModVerified = nil
+	}
+	if sourceData.ProofFacVerified == nil {
+		newData.ProofFacVerified = nil
 	}
 	return newData
 }
@@ -0,0 +1,227 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SaveDataVersion is the PersistedSaveData schema version written by MarshalSaveData
+	SaveDataVersion = 1
+)
+
+// ErrUnknownSaveDataVersion is returned when a persisted record was written with a schema this version of tss-lib does not know
+var ErrUnknownSaveDataVersion = errors.New("unknown save data version")
+
+// MarshalSaveData encodes the save data into the versioned PersistedSaveData protobuf format.
+// The curve is taken from ECDSAPub and must be present in the tss curve registry.
+func MarshalSaveData(save *LocalPartySaveData) ([]byte, error) {
+	pb, err := save.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// UnmarshalSaveData decodes save data previously encoded with MarshalSaveData.
+// An error wrapping ErrUnknownSaveDataVersion is returned for records written by a newer schema.
+func UnmarshalSaveData(bz []byte) (*LocalPartySaveData, error) {
+	pb := new(PersistedSaveData)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	return NewSaveDataFromProto(pb)
+}
+
+// ConvertJSONSaveData converts save data persisted as JSON of the LocalPartySaveData struct into the PersistedSaveData format.
+// Points without a curve name in the JSON are assumed to be on the default curve returned by tss.EC().
+func ConvertJSONSaveData(jsonBz []byte) ([]byte, error) {
+	save := new(LocalPartySaveData)
+	if err := json.Unmarshal(jsonBz, save); err != nil {
+		return nil, err
+	}
+	return MarshalSaveData(save)
+}
+
+// ToProto converts the save data into its versioned protobuf representation
+func (save *LocalPartySaveData) ToProto() (*PersistedSaveData, error) {
+	if save.ECDSAPub == nil {
+		return nil, errors.New("ToProto: the save data has no ECDSAPub")
+	}
+	ec := save.ECDSAPub.Curve()
+	ecName, ok := tss.GetCurveName(ec)
+	if !ok {
+		return nil, fmt.Errorf("ToProto: cannot find %T name in curve registry", ec)
+	}
+	if len(save.NTildej) != len(save.Ks) || len(save.H1j) != len(save.Ks) || len(save.H2j) != len(save.Ks) ||
+		len(save.BigXj) != len(save.Ks) || len(save.PaillierPKs) != len(save.Ks) {
+		return nil, errors.New("ToProto: the per-party slices in the save data have different lengths")
+	}
+	if (save.ProofModVerified != nil && len(save.ProofModVerified) != len(save.Ks)) ||
+		(save.ProofFacVerified != nil && len(save.ProofFacVerified) != len(save.Ks)) {
+		return nil, errors.New("ToProto: the proof records in the save data have an unexpected length")
+	}
+	pb := &PersistedSaveData{
+		Version:   SaveDataVersion,
+		Curve:     string(ecName),
+		NTildeI:   bigIntBytes(save.NTildei),
+		H1I:       bigIntBytes(save.H1i),
+		H2I:       bigIntBytes(save.H2i),
+		Alpha:     bigIntBytes(save.Alpha),
+		Beta:      bigIntBytes(save.Beta),
+		P:         bigIntBytes(save.P),
+		Q:         bigIntBytes(save.Q),
+		Xi:        bigIntBytes(save.Xi),
+		ShareId:   bigIntBytes(save.ShareID),
+		Ks:        bigIntsBytes(save.Ks),
+		NTildeJ:   bigIntsBytes(save.NTildej),
+		H1J:       bigIntsBytes(save.H1j),
+		H2J:       bigIntsBytes(save.H2j),
+		BigXJ:     make([]*PersistedSaveData_ECPoint, len(save.BigXj)),
+		EcdsaPub:  ecPointToProto(save.ECDSAPub),
+		ChainCode: save.ChainCode,
+
+		ProofModVerified: save.ProofModVerified,
+		ProofFacVerified: save.ProofFacVerified,
+	}
+	if sk := save.PaillierSK; sk != nil {
+		pb.PaillierSk = &PersistedSaveData_PaillierPrivateKey{
+			N:       bigIntBytes(sk.N),
+			LambdaN: bigIntBytes(sk.LambdaN),
+			PhiN:    bigIntBytes(sk.PhiN),
+			P:       bigIntBytes(sk.P),
+			Q:       bigIntBytes(sk.Q),
+		}
+	}
+	for j, Xj := range save.BigXj {
+		pb.BigXJ[j] = ecPointToProto(Xj)
+	}
+	pb.PaillierPks = make([][]byte, len(save.PaillierPKs))
+	for j, pk := range save.PaillierPKs {
+		if pk != nil {
+			pb.PaillierPks[j] = bigIntBytes(pk.N)
+		}
+	}
+	return pb, nil
+}
+
+// NewSaveDataFromProto converts a PersistedSaveData record back into LocalPartySaveData
+func NewSaveDataFromProto(pb *PersistedSaveData) (*LocalPartySaveData, error) {
+	switch v := pb.GetVersion(); {
+	case v == 0:
+		return nil, errors.New("NewSaveDataFromProto: the record has no version")
+	case v > SaveDataVersion:
+		return nil, fmt.Errorf("%w: record version %d, latest supported %d", ErrUnknownSaveDataVersion, v, SaveDataVersion)
+	}
+	ec, ok := tss.GetCurveByName(tss.CurveName(pb.GetCurve()))
+	if !ok {
+		return nil, fmt.Errorf("NewSaveDataFromProto: cannot find curve named %q in curve registry", pb.GetCurve())
+	}
+	partyCount := len(pb.GetKs())
+	if len(pb.GetNTildeJ()) != partyCount || len(pb.GetH1J()) != partyCount || len(pb.GetH2J()) != partyCount ||
+		len(pb.GetBigXJ()) != partyCount || len(pb.GetPaillierPks()) != partyCount {
+		return nil, errors.New("NewSaveDataFromProto: the per-party fields in the record have different lengths")
+	}
+	if n := len(pb.GetProofModVerified()); n != 0 && n != partyCount {
+		return nil, errors.New("NewSaveDataFromProto: proof_mod_verified has an unexpected length")
+	}
+	if n := len(pb.GetProofFacVerified()); n != 0 && n != partyCount {
+		return nil, errors.New("NewSaveDataFromProto: proof_fac_verified has an unexpected length")
+	}
+	save := NewLocalPartySaveData(partyCount)
+	// records written before the proofs were tracked leave these nil
+	save.ProofModVerified, save.ProofFacVerified = nil, nil
+	if len(pb.GetProofModVerified()) != 0 {
+		save.ProofModVerified = pb.GetProofModVerified()
+	}
+	if len(pb.GetProofFacVerified()) != 0 {
+		save.ProofFacVerified = pb.GetProofFacVerified()
+	}
+	save.NTildei = bigIntFromBytes(pb.GetNTildeI())
+	save.H1i, save.H2i = bigIntFromBytes(pb.GetH1I()), bigIntFromBytes(pb.GetH2I())
+	save.Alpha, save.Beta = bigIntFromBytes(pb.GetAlpha()), bigIntFromBytes(pb.GetBeta())
+	save.P, save.Q = bigIntFromBytes(pb.GetP()), bigIntFromBytes(pb.GetQ())
+	save.Xi, save.ShareID = bigIntFromBytes(pb.GetXi()), bigIntFromBytes(pb.GetShareId())
+	if sk := pb.GetPaillierSk(); sk != nil {
+		save.PaillierSK = &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: bigIntFromBytes(sk.GetN())},
+			LambdaN:   bigIntFromBytes(sk.GetLambdaN()),
+			PhiN:      bigIntFromBytes(sk.GetPhiN()),
+			P:         bigIntFromBytes(sk.GetP()),
+			Q:         bigIntFromBytes(sk.GetQ()),
+		}
+	}
+	var err error
+	for j := 0; j < partyCount; j++ {
+		save.Ks[j] = bigIntFromBytes(pb.GetKs()[j])
+		save.NTildej[j] = bigIntFromBytes(pb.GetNTildeJ()[j])
+		save.H1j[j] = bigIntFromBytes(pb.GetH1J()[j])
+		save.H2j[j] = bigIntFromBytes(pb.GetH2J()[j])
+		if save.BigXj[j], err = ecPointFromProto(ec, pb.GetBigXJ()[j]); err != nil {
+			return nil, fmt.Errorf("NewSaveDataFromProto: BigXj[%d]: %v", j, err)
+		}
+		if N := bigIntFromBytes(pb.GetPaillierPks()[j]); N != nil {
+			save.PaillierPKs[j] = &paillier.PublicKey{N: N}
+		}
+	}
+	if save.ECDSAPub, err = ecPointFromProto(ec, pb.GetEcdsaPub()); err != nil {
+		return nil, fmt.Errorf("NewSaveDataFromProto: ECDSAPub: %v", err)
+	}
+	if len(pb.GetChainCode()) != 0 {
+		save.ChainCode = pb.GetChainCode()
+	}
+	return &save, nil
+}
+
+// ----- //
+
+func ecPointToProto(p *crypto.ECPoint) *PersistedSaveData_ECPoint {
+	if p == nil {
+		return nil
+	}
+	return &PersistedSaveData_ECPoint{X: p.X().Bytes(), Y: p.Y().Bytes()}
+}
+
+func ecPointFromProto(ec elliptic.Curve, pb *PersistedSaveData_ECPoint) (*crypto.ECPoint, error) {
+	if pb == nil {
+		return nil, errors.New("missing point")
+	}
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(pb.GetX()), new(big.Int).SetBytes(pb.GetY()))
+}
+
+func bigIntBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
+
+func bigIntsBytes(is []*big.Int) [][]byte {
+	bzs := make([][]byte, len(is))
+	for j, i := range is {
+		bzs[j] = bigIntBytes(i)
+	}
+	return bzs
+}
+
+func bigIntFromBytes(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSaveDataProtoRoundTrip(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	for i, fixture := range fixtures {
+		fixture.ProofModVerified, fixture.ProofFacVerified = make([]bool, len(fixture.Ks)), make([]bool, len(fixture.Ks))
+		fixture.ProofModVerified[i], fixture.ProofFacVerified[i] = true, true
+		fixture.ChainCode = bytes.Repeat([]byte{byte(i + 1)}, ChainCodeLen)
+		bz, err := MarshalSaveData(&fixture)
+		assert.NoError(t, err)
+		decoded, err := UnmarshalSaveData(bz)
+		assert.NoError(t, err)
+		assert.Equal(t, fixture.Xi, decoded.Xi)
+		assert.Equal(t, fixture.ShareID, decoded.ShareID)
+		assert.Equal(t, fixture.Ks, decoded.Ks)
+		assert.Equal(t, fixture.NTildej, decoded.NTildej)
+		assert.Equal(t, fixture.H1j, decoded.H1j)
+		assert.Equal(t, fixture.H2j, decoded.H2j)
+		assert.Equal(t, fixture.PaillierPKs, decoded.PaillierPKs)
+		assert.Equal(t, fixture.LocalPreParams, decoded.LocalPreParams)
+		assert.Equal(t, fixture.ProofModVerified, decoded.ProofModVerified)
+		assert.Equal(t, fixture.ProofFacVerified, decoded.ProofFacVerified)
+		assert.Equal(t, fixture.ChainCode, decoded.ChainCode)
+		assert.True(t, fixture.ECDSAPub.Equals(decoded.ECDSAPub))
+		for j := range fixture.BigXj {
+			assert.True(t, fixture.BigXj[j].Equals(decoded.BigXj[j]))
+		}
+	}
+}
+
+func TestConvertJSONSaveData(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	jsonBz, err := json.Marshal(fixtures[0])
+	assert.NoError(t, err)
+	bz, err := ConvertJSONSaveData(jsonBz)
+	assert.NoError(t, err)
+	decoded, err := UnmarshalSaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, fixtures[0].Xi, decoded.Xi)
+	assert.True(t, fixtures[0].ECDSAPub.Equals(decoded.ECDSAPub))
+}
+
+func TestUnmarshalSaveDataUnknownVersion(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	pb, err := fixtures[0].ToProto()
+	assert.NoError(t, err)
+
+	pb.Version = SaveDataVersion + 1
+	bz, err := proto.Marshal(pb)
+	assert.NoError(t, err)
+	_, err = UnmarshalSaveData(bz)
+	assert.True(t, errors.Is(err, ErrUnknownSaveDataVersion), "a newer version should be rejected")
+
+	pb.Version = 0
+	bz, err = proto.Marshal(pb)
+	assert.NoError(t, err)
+	_, err = UnmarshalSaveData(bz)
+	assert.Error(t, err, "a record without a version should be rejected")
+}
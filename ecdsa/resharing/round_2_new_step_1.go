@@ -8,7 +8,9 @@ package resharing
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
@@ -51,6 +53,21 @@ func (round *round2) Start() *tss.Error {
 	}
 	round.temp.ssid = SSID
 
+	// check that the old committee agrees on the chain code and SAVE it; it is empty for keys without one
+	chainCode := r1msg.GetChainCode()
+	for j, Pj := range round.OldParties().IDs() {
+		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
+		if !bytes.Equal(chainCode, r1msg.GetChainCode()) {
+			return round.WrapError(errors.New("chain code mismatch"), Pj)
+		}
+	}
+	if len(chainCode) != 0 {
+		if len(chainCode) != keygen.ChainCodeLen {
+			return round.WrapError(fmt.Errorf("the chain code must be %d bytes", keygen.ChainCodeLen))
+		}
+		round.save.ChainCode = chainCode
+	}
+
 	// 2. "broadcast" "ACK" members of the OLD committee
 	r2msg1 := NewDGRound2Message2(
 		round.OldParties().IDs().Exclude(round.PartyID()), round.PartyID())
@@ -67,12 +84,18 @@ func (round *round2) Start() *tss.Error {
 		return round.WrapError(
 			errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
 	} else if round.save.LocalPreParams.ValidateWithProof() {
+		if round.save.LocalPreParams.ModulusLen() < round.PaillierModulusLen() {
+			return round.WrapError(fmt.Errorf("`optionalPreParams` are shorter than the required Paillier modulus length of %d bits",
+				round.PaillierModulusLen()), Pi)
+		}
 		preParams = &round.save.LocalPreParams
 	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), round.SafePrimeGenTimeout())
+		defer cancel()
 		var err error
-		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout(), round.Concurrency())
+		preParams, err = keygen.GeneratePreParamsWithModulusLen(ctx, round.Rand(), round.PaillierModulusLen(), round.Concurrency())
 		if err != nil {
-			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+			return round.WrapError(fmt.Errorf("pre-params generation failed: %v", err), Pi)
 		}
 	}
 	round.save.LocalPreParams = *preParams
@@ -62,6 +62,12 @@ func (round *round4) Start() *tss.Error {
 			r2msg1.UnmarshalNTilde(),
 			r2msg1.UnmarshalH1(),
 			r2msg1.UnmarshalH2()
+		if paiPK.N.BitLen() < round.PaillierModulusLen() {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() < round.PaillierModulusLen() {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
 		if H1j.Cmp(H2j) == 0 {
 			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
 		}
@@ -88,7 +94,9 @@ func (round *round4) Start() *tss.Error {
 			if ok := modProof.Verify(ContextJ, paiPK.N); !ok {
 				paiProofCulprits[j] = msg.GetFrom()
 				common.Logger.Warningf("modProof verify failed for party %s", msg.GetFrom(), err)
+				return
 			}
+			round.save.ProofModVerified[j] = true
 		}(j, msg, r2msg1)
 		_j := j
 		_msg := msg
@@ -154,7 +162,7 @@ func (round *round4) Start() *tss.Error {
 		r3msg1 := round.temp.dgRound3Message1s[j].Content().(*DGRound3Message1)
 		sharej := &vss.Share{
 			Threshold: round.NewThreshold(),
-			ID:        round.PartyID().KeyInt(),
+			ID:        round.NewShareIndex(),
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
 		if ok := sharej.Verify(round.Params().EC(), round.NewThreshold(), vj); !ok {
@@ -190,7 +198,7 @@ func (round *round4) Start() *tss.Error {
 	paiProofCulprits = make([]*tss.PartyID, 0, round.NewPartyCount()) // who caused the error(s)
 	for j := 0; j < round.NewPartyCount(); j++ {
 		Pj := round.NewParties().IDs()[j]
-		kj := Pj.KeyInt()
+		kj := round.NewShareIndexes()[j]
 		newBigXj := Vc[0]
 		newKs = append(newKs, kj)
 		z := new(big.Int).SetInt64(int64(1))
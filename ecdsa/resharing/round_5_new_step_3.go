@@ -32,7 +32,7 @@ func (round *round5) Start() *tss.Error {
 		// for this P: SAVE data
 		ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
 		round.save.BigXj = round.temp.newBigXjs
-		round.save.ShareID = round.PartyID().KeyInt()
+		round.save.ShareID = round.NewShareIndex()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
 
@@ -44,6 +44,7 @@ func (round *round5) Start() *tss.Error {
 			r2msg1 := msg.Content().(*DGRound2Message1)
 			round.save.PaillierPKs[j] = r2msg1.UnmarshalPaillierPK()
 		}
+		round.save.ProofFacVerified[i] = true // our own key needs no proof
 		for j, msg := range round.temp.dgRound4Message1s {
 			if j == i {
 				continue
@@ -62,6 +63,7 @@ func (round *round5) Start() *tss.Error {
 					common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
 					return round.WrapError(err, round.NewParties().IDs()[j])
 				}
+				round.save.ProofFacVerified[j] = true
 			}
 
 		}
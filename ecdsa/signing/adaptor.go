@@ -0,0 +1,198 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// ECDSA adaptor signatures, e.g. for atomic swaps. With R = k^-1 * G the nonce of the signing protocol and Y = y * G the
+// adaptor point, the parties compute R_Y = k^-1 * Y and the pre-signature S = k(m + r*x) with r = R_Y.x. Anyone can
+// check it against R with the proof that R and R_Y have the same discrete logarithm; whoever learns y completes it into
+// the signature (r, S * y^-1), whose nonce is R_Y, and y is then extracted from the two as S * s^-1.
+
+// adaptorSession is the session of the DLEQ proof of an adaptor signature, which is verified by other parties than
+// the signers
+var adaptorSession = []byte("tss-lib ecdsa adaptor signature")
+
+// AdaptorSignatureData is a pre-signature of M locked to the adaptor point Y. R and RY are the nonces of the
+// pre-signature and of the completed signature, and Proof proves that they have the same discrete logarithm with
+// respect to the generator and to Y.
+type AdaptorSignatureData struct {
+	Y, R, RY *crypto.ECPoint
+	S        *big.Int
+	Proof    *schnorr.ZKDLEQProof
+	M        []byte
+}
+
+// NewLocalPartyWithAdaptor returns a party computing a pre-signature of `digest` locked to `adaptor`, see
+// NewLocalPartyWithDigest. It is sent to `end` instead of a signature; whoever learns the discrete logarithm of
+// `adaptor` can complete it with AdaptorSignatureData.Complete.
+func NewLocalPartyWithAdaptor(
+	digest []byte,
+	adaptor *crypto.ECPoint,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *AdaptorSignatureData,
+) (tss.Party, error) {
+	if len(digest) == 0 {
+		return nil, errors.New("signing.NewLocalPartyWithAdaptor expected a non-empty digest")
+	}
+	if adaptor == nil || !adaptor.ValidateBasic() || !adaptor.IsOnCurve() || !tss.SameCurve(adaptor.Curve(), params.EC()) {
+		return nil, errors.New("signing.NewLocalPartyWithAdaptor expected an adaptor point on the curve of the key")
+	}
+	p := NewLocalPartyWithDigest(digest, params, key, out, nil).(*LocalParty)
+	partyCount := len(params.Parties().IDs())
+	p.temp.adaptor = adaptor
+	p.temp.adaptorEnd = end
+	p.temp.adaptorGammas = make([]*crypto.ECPoint, partyCount)
+	p.temp.adaptorAs = make([]*crypto.ECPoint, partyCount)
+	p.temp.adaptorBs = make([]*crypto.ECPoint, partyCount)
+	return p, nil
+}
+
+// Verify checks that the pre-signature is locked to `adaptor` and completes into a signature by the key `pub`
+func (sig *AdaptorSignatureData) Verify(pub, adaptor *crypto.ECPoint) error {
+	if sig == nil || sig.Y == nil || sig.R == nil || sig.RY == nil || sig.S == nil || sig.Proof == nil {
+		return errors.New("the adaptor signature is incomplete")
+	}
+	if !sig.Y.Equals(adaptor) {
+		return errors.New("the adaptor signature is locked to another adaptor point")
+	}
+	ec := pub.Curve()
+	N := ec.Params().N
+	if sig.S.Sign() <= 0 || sig.S.Cmp(N) >= 0 {
+		return errors.New("the adaptor signature has an invalid S")
+	}
+	if !sig.R.IsOnCurve() || !sig.RY.IsOnCurve() {
+		return errors.New("the adaptor signature has a nonce that is not on the curve")
+	}
+	if !sig.Proof.Verify(adaptorSession, sig.R, sig.Y, sig.RY) {
+		return errors.New("the nonces of the adaptor signature do not have the same discrete logarithm")
+	}
+	// S^-1 * (m * G + r * X) == R
+	modN := common.ModInt(N)
+	sInv := modN.ModInverse(sig.S)
+	r := new(big.Int).Mod(sig.RY.X(), N)
+	u1 := modN.Mul(digestToInt(sig.M, ec), sInv)
+	u2 := modN.Mul(r, sInv)
+	x1, y1 := ec.ScalarBaseMult(u1.Bytes())
+	x2, y2 := ec.ScalarMult(pub.X(), pub.Y(), u2.Bytes())
+	x, y := ec.Add(x1, y1, x2, y2)
+	if !sig.R.Equals(crypto.NewECPointNoCurveCheck(ec, x, y)) {
+		return errors.New("the adaptor signature does not verify")
+	}
+	return nil
+}
+
+// Complete returns the signature completed with `y`, the discrete logarithm of the adaptor point. S is normalized
+// according to `lowS`, as in the signatures of the protocol.
+func (sig *AdaptorSignatureData) Complete(y *big.Int, lowS tss.LowSMode) (*common.SignatureData, error) {
+	ec := sig.Y.Curve()
+	N := ec.Params().N
+	if y == nil || y.Sign() <= 0 || y.Cmp(N) >= 0 || !crypto.ScalarBaseMult(ec, y).Equals(sig.Y) {
+		return nil, errors.New("y is not the discrete logarithm of the adaptor point")
+	}
+	modN := common.ModInt(N)
+	s := modN.Mul(sig.S, modN.ModInverse(y))
+	s, recid := normalizeS(ec, s, recoveryID(sig.RY), lowS)
+
+	orderBytes := (N.BitLen() + 7) / 8
+	data := &common.SignatureData{
+		R:                 padToLengthBytesInPlace(new(big.Int).Mod(sig.RY.X(), N).Bytes(), orderBytes),
+		S:                 padToLengthBytesInPlace(s.Bytes(), orderBytes),
+		SignatureRecovery: []byte{recid},
+		M:                 sig.M,
+	}
+	data.Signature = append(append([]byte{}, data.R...), data.S...)
+	return data, nil
+}
+
+// ExtractSecret returns the discrete logarithm of the adaptor point from `data`, the signature completed from the
+// pre-signature, e.g. once it has been published on chain
+func (sig *AdaptorSignatureData) ExtractSecret(data *common.SignatureData) (*big.Int, error) {
+	ec := sig.Y.Curve()
+	N := ec.Params().N
+	r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+	if r.Cmp(new(big.Int).Mod(sig.RY.X(), N)) != 0 {
+		return nil, errors.New("the signature was not completed from the adaptor signature")
+	}
+	if s.Sign() <= 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("the signature has an invalid S")
+	}
+	// s may have been negated to make it low
+	modN := common.ModInt(N)
+	y := modN.Mul(sig.S, modN.ModInverse(s))
+	if crypto.ScalarBaseMult(ec, y).Equals(sig.Y) {
+		return y, nil
+	}
+	if y = new(big.Int).Sub(N, y); crypto.ScalarBaseMult(ec, y).Equals(sig.Y) {
+		return y, nil
+	}
+	return nil, errors.New("the signature was not completed from the adaptor signature")
+}
+
+// ----- //
+
+// verifyAdaptorProofs returns the parties whose share z_j of the response of the DLEQ proof does not match their
+// commitments: z_j * G == A_j + c * theta^-1 * Gamma_j and z_j * Y == B_j + c * theta^-1 * gamma_j * Y
+func (round *finalization) verifyAdaptorProofs() []*tss.PartyID {
+	ec := round.Params().EC()
+	N := ec.Params().N
+	modN := common.ModInt(N)
+	cThetaInv := modN.Mul(round.temp.adaptorC, round.temp.thetaInverse)
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		zj := r9msg.UnmarshalAdaptorT()
+		if zj.Cmp(N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		aj, err := round.temp.adaptorAs[j].Add(round.temp.bigGammas[j].ScalarMult(cThetaInv))
+		if err != nil || !crypto.ScalarBaseMult(ec, zj).Equals(aj) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		bj, err := round.temp.adaptorBs[j].Add(round.temp.adaptorGammas[j].ScalarMult(cThetaInv))
+		if err != nil || !round.temp.adaptor.ScalarMult(zj).Equals(bj) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
+// finalizeAdaptor outputs the pre-signature `sumS` with the proof aggregated from the shares z_j
+func (round *finalization) finalizeAdaptor(sumS *big.Int) *tss.Error {
+	modN := common.ModInt(round.Params().EC().Params().N)
+	z := big.NewInt(0)
+	for j := range round.Parties().IDs() {
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		z = modN.Add(z, r9msg.UnmarshalAdaptorT())
+	}
+	sig := &AdaptorSignatureData{
+		Y:     round.temp.adaptor,
+		R:     round.temp.bigR,
+		RY:    round.temp.bigRY,
+		S:     sumS,
+		Proof: &schnorr.ZKDLEQProof{Alpha: round.temp.adaptorA, Beta: round.temp.adaptorB, T: z},
+		M:     round.temp.digest,
+	}
+	if err := sig.Verify(round.key.ECDSAPub, round.temp.adaptor); err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.adaptorEnd <- sig
+	return nil
+}
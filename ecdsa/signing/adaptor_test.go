@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	crypto2 "github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EWithAdaptor(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	N := tss.S256().Params().N
+	y := common.GetRandomPositiveInt(rand.Reader, N)
+	Y := crypto2.ScalarBaseMult(tss.S256(), y)
+	digest := common.SHA512_256([]byte("atomic swap"))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *AdaptorSignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P, err := NewLocalPartyWithAdaptor(digest, Y, params, keys[i], outCh, endCh)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	adaptorSigs := make([]*AdaptorSignatureData, 0, len(signPIDs))
+	for len(adaptorSigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			adaptorSigs = append(adaptorSigs, data)
+		}
+	}
+
+	pub := keys[0].ECDSAPub
+	for _, adaptorSig := range adaptorSigs {
+		assert.Equal(t, digest, adaptorSig.M)
+		assert.NoError(t, adaptorSig.Verify(pub, Y), "the adaptor signature should verify")
+		assert.Equal(t, 0, adaptorSigs[0].S.Cmp(adaptorSig.S), "every party should output the same adaptor signature")
+	}
+	adaptorSig := adaptorSigs[0]
+	ok := ecdsa.Verify(pub.ToECDSAPubKey(), digest, new(big.Int).Mod(adaptorSig.RY.X(), N), adaptorSig.S)
+	assert.False(t, ok, "the adaptor signature must not verify as a signature")
+
+	otherY := crypto2.ScalarBaseMult(tss.S256(), big.NewInt(42))
+	assert.Error(t, adaptorSig.Verify(pub, otherY), "the adaptor signature is locked to Y")
+	tampered := *adaptorSig
+	tampered.M = common.SHA512_256([]byte("another swap"))
+	assert.Error(t, tampered.Verify(pub, Y), "the adaptor signature is of another message")
+	tampered = *adaptorSig
+	tampered.RY = otherY
+	assert.Error(t, tampered.Verify(pub, Y), "R_Y must have the discrete logarithm of R")
+
+	_, err = adaptorSig.Complete(big.NewInt(42), tss.LowSAlways)
+	assert.Error(t, err, "only y completes the adaptor signature")
+	for _, mode := range []tss.LowSMode{tss.LowSAlways, tss.LowSNever} {
+		data, err := adaptorSig.Complete(y, mode)
+		if !assert.NoError(t, err) {
+			return
+		}
+		r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+		assert.True(t, ecdsa.Verify(pub.ToECDSAPubKey(), digest, r, s), "the completed signature should verify")
+		if mode == tss.LowSAlways {
+			assert.True(t, s.Cmp(new(big.Int).Rsh(N, 1)) <= 0, "s should be low")
+		}
+		recovered, err := RecoverPublicKey(tss.S256(), digest, data)
+		if assert.NoError(t, err) {
+			assert.True(t, pub.Equals(recovered), "the recovery ID should match the completed signature")
+		}
+
+		extracted, err := adaptorSig.ExtractSecret(data)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, y.Cmp(extracted), "y should be extracted from the completed signature")
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*BatchLocalParty)(nil)
+	_ fmt.Stringer = (*BatchLocalParty)(nil)
+)
+
+type (
+	// BatchLocalParty signs several messages with the same key and signers in the rounds of a single signing session.
+	// It runs a session per message in lockstep and bundles the messages of the same round of every session into a
+	// SignBatchMessage, so that the batch takes as many round trips as signing a single message.
+	//
+	// Only the round trips are shared. The MtA and the proofs are not vectorised: each message has its own, so the
+	// computation and the size of the batch messages grow linearly with the number of messages.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+		mtx    sync.Mutex
+
+		parties []*LocalParty
+		// outbound messages of each session that were not bundled yet; filled while the sessions run
+		pending    [][]tss.Message
+		sessionOut []chan tss.Message
+		sessionEnd []chan *common.SignatureData
+		data       []*common.SignatureData
+		ended      bool
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []*common.SignatureData
+	}
+)
+
+// NewBatchLocalParty returns a party signing each of `msgs`. The signatures are sent through `end` once all are
+// completed, in the order of `msgs`. Every signer must give the same messages in the same order.
+func NewBatchLocalParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	return newBatchLocalParty(len(msgs), params, out, end,
+		func(k int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalParty(msgs[k], params, key, out, end, fullBytesLen...)
+		})
+}
+
+func newBatchLocalParty(
+	count int,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+	newSession func(k int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party,
+) tss.Party {
+	if count == 0 {
+		panic(errors.New("signing.NewBatchLocalParty expected at least one message"))
+	}
+	p := &BatchLocalParty{
+		BaseParty:  new(tss.BaseParty),
+		params:     params,
+		parties:    make([]*LocalParty, count),
+		pending:    make([][]tss.Message, count),
+		sessionEnd: make([]chan *common.SignatureData, count),
+		sessionOut: make([]chan tss.Message, count),
+		data:       make([]*common.SignatureData, count),
+		out:        out,
+		end:        end,
+	}
+	for k := 0; k < count; k++ {
+		// unbuffered: forEachSession receives the messages while the session runs, however many it sends
+		p.sessionOut[k] = make(chan tss.Message)
+		p.sessionEnd[k] = make(chan *common.SignatureData, 1)
+		p.parties[k] = newSession(k, p.sessionOut[k], p.sessionEnd[k]).(*LocalParty)
+	}
+	return p
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	// a batch has no rounds of its own, they are those of its sessions
+	return nil
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if err := p.forEachSession(func(k int, P *LocalParty) *tss.Error {
+		return P.Start()
+	}); err != nil {
+		return err
+	}
+	return p.flush()
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	parts, err := p.unbundle(msg)
+	if err != nil {
+		return false, err
+	}
+	if err := p.forEachSession(func(k int, P *LocalParty) *tss.Error {
+		_, err := P.Update(parts[k])
+		return err
+	}); err != nil {
+		return false, err
+	}
+	return true, p.flush()
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	content, ok := msg.Content().(*SignBatchMessage)
+	if !ok {
+		return false, p.WrapError(fmt.Errorf("expected a batch message: %s", msg), msg.GetFrom())
+	}
+	if len(content.GetMessages()) != len(p.parties) {
+		return false, p.WrapError(fmt.Errorf("expected a message for each of the %d sessions, got %d",
+			len(p.parties), len(content.GetMessages())), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	parts, err := p.unbundle(msg)
+	if err != nil {
+		return false, err
+	}
+	for k, P := range p.parties {
+		if ok, err := P.StoreMessage(parts[k]); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) Running() bool {
+	for _, P := range p.parties {
+		if P.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *BatchLocalParty) WaitingFor() []*tss.PartyID {
+	waiting := make(map[int]bool)
+	ids := make([]*tss.PartyID, 0, len(p.params.Parties().IDs()))
+	for _, P := range p.parties {
+		for _, Pj := range P.WaitingFor() {
+			if !waiting[Pj.Index] {
+				waiting[Pj.Index] = true
+				ids = append(ids, Pj)
+			}
+		}
+	}
+	return ids
+}
+
+func (p *BatchLocalParty) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return p.parties[0].WrapError(err, culprits...)
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, batch of %d, %s", p.PartyID(), len(p.parties), p.parties[0].BaseParty.String())
+}
+
+// ----- //
+
+// forEachSession runs `fn` on the party of every session concurrently, returning the error of the first session that failed.
+// The messages that a session sends meanwhile are added to its pending messages, so that it never blocks on sending.
+func (p *BatchLocalParty) forEachSession(fn func(k int, P *LocalParty) *tss.Error) *tss.Error {
+	errs := make([]*tss.Error, len(p.parties))
+	wg := sync.WaitGroup{}
+	wg.Add(len(p.parties))
+	for k, P := range p.parties {
+		done := make(chan struct{})
+		go func(k int, P *LocalParty) {
+			defer close(done)
+			errs[k] = fn(k, P)
+		}(k, P)
+		go func(k int) {
+			defer wg.Done()
+			for {
+				select {
+				case msg := <-p.sessionOut[k]:
+					p.pending[k] = append(p.pending[k], msg)
+				case <-done:
+					// the sessions send from the goroutine that runs them, so nothing is sent after fn returns
+					return
+				}
+			}
+		}(k)
+	}
+	wg.Wait()
+	for k, err := range errs {
+		if err != nil {
+			return tss.NewError(fmt.Errorf("message %d: %w", k, err.Cause()), TaskName, err.Round(), err.Victim(), err.Culprits()...)
+		}
+	}
+	return nil
+}
+
+// unbundle parses the message of each session from a batch message
+func (p *BatchLocalParty) unbundle(msg tss.ParsedMessage) ([]tss.ParsedMessage, *tss.Error) {
+	content := msg.Content().(*SignBatchMessage)
+	parts := make([]tss.ParsedMessage, len(p.parties))
+	for k, bz := range content.GetMessages() {
+		part, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+		if err != nil {
+			return nil, p.WrapError(fmt.Errorf("message %d: %w", k, err), msg.GetFrom())
+		}
+		if _, ok := part.Content().(*SignBatchMessage); ok {
+			return nil, p.WrapError(fmt.Errorf("message %d: batch messages cannot be nested", k), msg.GetFrom())
+		}
+		parts[k] = part
+	}
+	return parts, nil
+}
+
+// flush bundles the outbound messages that every session has sent, and sends the signatures once all are completed
+func (p *BatchLocalParty) flush() *tss.Error {
+	for {
+		ready := true
+		for _, pending := range p.pending {
+			ready = ready && len(pending) > 0
+		}
+		if !ready {
+			break
+		}
+		parts := make([][]byte, len(p.pending))
+		var routing *tss.MessageRouting
+		for k := range p.pending {
+			msg := p.pending[k][0]
+			p.pending[k] = p.pending[k][1:]
+			bz, r, err := msg.WireBytes()
+			if err != nil {
+				return p.WrapError(err)
+			}
+			if routing == nil {
+				routing = r
+			} else if !sameRouting(routing, r) {
+				return p.WrapError(errors.New("the sessions of the batch are out of step"))
+			}
+			parts[k] = bz
+		}
+		p.out <- NewSignBatchMessage(*routing, parts)
+	}
+
+	if p.ended {
+		return nil
+	}
+	for k, ch := range p.sessionEnd {
+		if p.data[k] != nil {
+			continue
+		}
+		select {
+		case data := <-ch:
+			p.data[k] = data
+		default:
+			return nil
+		}
+	}
+	p.ended = true
+	p.end <- p.data
+	return nil
+}
+
+func sameRouting(r1, r2 *tss.MessageRouting) bool {
+	if r1.IsBroadcast != r2.IsBroadcast || len(r1.To) != len(r2.To) {
+		return false
+	}
+	for j := range r1.To {
+		if r1.To[j].Index != r2.To[j].Index {
+			return false
+		}
+	}
+	return true
+}
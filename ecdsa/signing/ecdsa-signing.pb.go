@@ -6,8 +6,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.14.0
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
 // source: protob/ecdsa-signing.proto
 
 package signing
@@ -26,7 +26,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-//
 // Represents a P2P message sent to each party during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message1 struct {
 	state         protoimpl.MessageState
@@ -83,7 +82,6 @@ func (x *SignRound1Message1) GetRangeProofAlice() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message2 struct {
 	state         protoimpl.MessageState
@@ -132,7 +130,6 @@ func (x *SignRound1Message2) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a P2P message sent to each party during Round 2 of the ECDSA TSS signing protocol.
 type SignRound2Message struct {
 	state         protoimpl.MessageState
@@ -205,7 +202,6 @@ func (x *SignRound2Message) GetProofBobWc() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 3 of the ECDSA TSS signing protocol.
 type SignRound3Message struct {
 	state         protoimpl.MessageState
@@ -254,7 +250,6 @@ func (x *SignRound3Message) GetTheta() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS signing protocol.
 type SignRound4Message struct {
 	state         protoimpl.MessageState
@@ -265,6 +260,15 @@ type SignRound4Message struct {
 	ProofAlphaX  []byte   `protobuf:"bytes,2,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
 	ProofAlphaY  []byte   `protobuf:"bytes,3,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
 	ProofT       []byte   `protobuf:"bytes,4,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	// gamma_i * Y for the adaptor point Y, with a proof that it has the discrete logarithm of Gamma_i; empty unless
+	// signing with an adaptor point
+	AdaptorGammaX      []byte `protobuf:"bytes,5,opt,name=adaptor_gamma_x,json=adaptorGammaX,proto3" json:"adaptor_gamma_x,omitempty"`
+	AdaptorGammaY      []byte `protobuf:"bytes,6,opt,name=adaptor_gamma_y,json=adaptorGammaY,proto3" json:"adaptor_gamma_y,omitempty"`
+	AdaptorProofAlphaX []byte `protobuf:"bytes,7,opt,name=adaptor_proof_alpha_x,json=adaptorProofAlphaX,proto3" json:"adaptor_proof_alpha_x,omitempty"`
+	AdaptorProofAlphaY []byte `protobuf:"bytes,8,opt,name=adaptor_proof_alpha_y,json=adaptorProofAlphaY,proto3" json:"adaptor_proof_alpha_y,omitempty"`
+	AdaptorProofBetaX  []byte `protobuf:"bytes,9,opt,name=adaptor_proof_beta_x,json=adaptorProofBetaX,proto3" json:"adaptor_proof_beta_x,omitempty"`
+	AdaptorProofBetaY  []byte `protobuf:"bytes,10,opt,name=adaptor_proof_beta_y,json=adaptorProofBetaY,proto3" json:"adaptor_proof_beta_y,omitempty"`
+	AdaptorProofT      []byte `protobuf:"bytes,11,opt,name=adaptor_proof_t,json=adaptorProofT,proto3" json:"adaptor_proof_t,omitempty"`
 }
 
 func (x *SignRound4Message) Reset() {
@@ -327,7 +331,55 @@ func (x *SignRound4Message) GetProofT() []byte {
 	return nil
 }
 
-//
+func (x *SignRound4Message) GetAdaptorGammaX() []byte {
+	if x != nil {
+		return x.AdaptorGammaX
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorGammaY() []byte {
+	if x != nil {
+		return x.AdaptorGammaY
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorProofAlphaX() []byte {
+	if x != nil {
+		return x.AdaptorProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorProofAlphaY() []byte {
+	if x != nil {
+		return x.AdaptorProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorProofBetaX() []byte {
+	if x != nil {
+		return x.AdaptorProofBetaX
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorProofBetaY() []byte {
+	if x != nil {
+		return x.AdaptorProofBetaY
+	}
+	return nil
+}
+
+func (x *SignRound4Message) GetAdaptorProofT() []byte {
+	if x != nil {
+		return x.AdaptorProofT
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to all parties during Round 5 of the ECDSA TSS signing protocol.
 type SignRound5Message struct {
 	state         protoimpl.MessageState
@@ -376,7 +428,6 @@ func (x *SignRound5Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 6 of the ECDSA TSS signing protocol.
 type SignRound6Message struct {
 	state         protoimpl.MessageState
@@ -481,7 +532,6 @@ func (x *SignRound6Message) GetVProofU() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 7 of the ECDSA TSS signing protocol.
 type SignRound7Message struct {
 	state         protoimpl.MessageState
@@ -530,7 +580,6 @@ func (x *SignRound7Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 8 of the ECDSA TSS signing protocol.
 type SignRound8Message struct {
 	state         protoimpl.MessageState
@@ -579,7 +628,6 @@ func (x *SignRound8Message) GetDeCommitment() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 9 of the ECDSA TSS signing protocol.
 type SignRound9Message struct {
 	state         protoimpl.MessageState
@@ -587,6 +635,12 @@ type SignRound9Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	S []byte `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	// the blinding factor l of V, revealed with s so that s can be checked against V; empty when signing with a
+	// presignature
+	L []byte `protobuf:"bytes,2,opt,name=l,proto3" json:"l,omitempty"`
+	// the share of the response of the proof that R and R_Y have the same discrete logarithm; empty unless signing
+	// with an adaptor point
+	AdaptorProofT []byte `protobuf:"bytes,3,opt,name=adaptor_proof_t,json=adaptorProofT,proto3" json:"adaptor_proof_t,omitempty"`
 }
 
 func (x *SignRound9Message) Reset() {
@@ -628,6 +682,411 @@ func (x *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+func (x *SignRound9Message) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+func (x *SignRound9Message) GetAdaptorProofT() []byte {
+	if x != nil {
+		return x.AdaptorProofT
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during the check round of presigning: a proof that k_i * R has the
+// plaintext of the encryption of k_i sent to the receiver in round 1 as its discrete logarithm.
+type SignPresignCheckMessage1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ProofPdlWSlack [][]byte `protobuf:"bytes,1,rep,name=proof_pdl_w_slack,json=proofPdlWSlack,proto3" json:"proof_pdl_w_slack,omitempty"`
+}
+
+func (x *SignPresignCheckMessage1) Reset() {
+	*x = SignPresignCheckMessage1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignPresignCheckMessage1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignPresignCheckMessage1) ProtoMessage() {}
+
+func (x *SignPresignCheckMessage1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignPresignCheckMessage1.ProtoReflect.Descriptor instead.
+func (*SignPresignCheckMessage1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SignPresignCheckMessage1) GetProofPdlWSlack() [][]byte {
+	if x != nil {
+		return x.ProofPdlWSlack
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during the check round of presigning: k_i * R, S_i = sigma_i * R
+// and T_i = sigma_i * G + l_i * R, with a proof that S_i and T_i have the same sigma_i.
+type SignPresignCheckMessage2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BarRX       []byte `protobuf:"bytes,1,opt,name=bar_r_x,json=barRX,proto3" json:"bar_r_x,omitempty"`
+	BarRY       []byte `protobuf:"bytes,2,opt,name=bar_r_y,json=barRY,proto3" json:"bar_r_y,omitempty"`
+	SX          []byte `protobuf:"bytes,3,opt,name=s_x,json=sX,proto3" json:"s_x,omitempty"`
+	SY          []byte `protobuf:"bytes,4,opt,name=s_y,json=sY,proto3" json:"s_y,omitempty"`
+	TX          []byte `protobuf:"bytes,5,opt,name=t_x,json=tX,proto3" json:"t_x,omitempty"`
+	TY          []byte `protobuf:"bytes,6,opt,name=t_y,json=tY,proto3" json:"t_y,omitempty"`
+	ProofAlphaX []byte `protobuf:"bytes,7,opt,name=proof_alpha_x,json=proofAlphaX,proto3" json:"proof_alpha_x,omitempty"`
+	ProofAlphaY []byte `protobuf:"bytes,8,opt,name=proof_alpha_y,json=proofAlphaY,proto3" json:"proof_alpha_y,omitempty"`
+	ProofBetaX  []byte `protobuf:"bytes,9,opt,name=proof_beta_x,json=proofBetaX,proto3" json:"proof_beta_x,omitempty"`
+	ProofBetaY  []byte `protobuf:"bytes,10,opt,name=proof_beta_y,json=proofBetaY,proto3" json:"proof_beta_y,omitempty"`
+	ProofT      []byte `protobuf:"bytes,11,opt,name=proof_t,json=proofT,proto3" json:"proof_t,omitempty"`
+	ProofU      []byte `protobuf:"bytes,12,opt,name=proof_u,json=proofU,proto3" json:"proof_u,omitempty"`
+}
+
+func (x *SignPresignCheckMessage2) Reset() {
+	*x = SignPresignCheckMessage2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignPresignCheckMessage2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignPresignCheckMessage2) ProtoMessage() {}
+
+func (x *SignPresignCheckMessage2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignPresignCheckMessage2.ProtoReflect.Descriptor instead.
+func (*SignPresignCheckMessage2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignPresignCheckMessage2) GetBarRX() []byte {
+	if x != nil {
+		return x.BarRX
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetBarRY() []byte {
+	if x != nil {
+		return x.BarRY
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetSX() []byte {
+	if x != nil {
+		return x.SX
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetSY() []byte {
+	if x != nil {
+		return x.SY
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetTX() []byte {
+	if x != nil {
+		return x.TX
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetTY() []byte {
+	if x != nil {
+		return x.TY
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofAlphaX() []byte {
+	if x != nil {
+		return x.ProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofAlphaY() []byte {
+	if x != nil {
+		return x.ProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofBetaX() []byte {
+	if x != nil {
+		return x.ProofBetaX
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofBetaY() []byte {
+	if x != nil {
+		return x.ProofBetaY
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofT() []byte {
+	if x != nil {
+		return x.ProofT
+	}
+	return nil
+}
+
+func (x *SignPresignCheckMessage2) GetProofU() []byte {
+	if x != nil {
+		return x.ProofU
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties when the phase 5 check failed, to identify the parties that cheated.
+type SignIdentifyRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	// the Paillier randomness of the encryption of k sent to each party
+	KRandomness [][]byte `protobuf:"bytes,2,rep,name=k_randomness,json=kRandomness,proto3" json:"k_randomness,omitempty"`
+	Gamma       []byte   `protobuf:"bytes,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Rho         []byte   `protobuf:"bytes,4,opt,name=rho,proto3" json:"rho,omitempty"`
+	L           []byte   `protobuf:"bytes,5,opt,name=l,proto3" json:"l,omitempty"`
+	// the shares of the sender as Bob in the MtA with each party: beta, and g^nu for the MtAwc
+	Beta [][]byte `protobuf:"bytes,6,rep,name=beta,proto3" json:"beta,omitempty"`
+	NuX  [][]byte `protobuf:"bytes,7,rep,name=nu_x,json=nuX,proto3" json:"nu_x,omitempty"`
+	NuY  [][]byte `protobuf:"bytes,8,rep,name=nu_y,json=nuY,proto3" json:"nu_y,omitempty"`
+}
+
+func (x *SignIdentifyRound1Message) Reset() {
+	*x = SignIdentifyRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignIdentifyRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentifyRound1Message) ProtoMessage() {}
+
+func (x *SignIdentifyRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentifyRound1Message.ProtoReflect.Descriptor instead.
+func (*SignIdentifyRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SignIdentifyRound1Message) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetKRandomness() [][]byte {
+	if x != nil {
+		return x.KRandomness
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetGamma() []byte {
+	if x != nil {
+		return x.Gamma
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetRho() []byte {
+	if x != nil {
+		return x.Rho
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetBeta() [][]byte {
+	if x != nil {
+		return x.Beta
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetNuX() [][]byte {
+	if x != nil {
+		return x.NuX
+	}
+	return nil
+}
+
+func (x *SignIdentifyRound1Message) GetNuY() [][]byte {
+	if x != nil {
+		return x.NuY
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties when signing failed, listing the parties whose revealed values do
+// not match the private messages that they sent to the sender.
+type SignIdentifyRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accused []uint32 `protobuf:"varint,1,rep,packed,name=accused,proto3" json:"accused,omitempty"`
+}
+
+func (x *SignIdentifyRound2Message) Reset() {
+	*x = SignIdentifyRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignIdentifyRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentifyRound2Message) ProtoMessage() {}
+
+func (x *SignIdentifyRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentifyRound2Message.ProtoReflect.Descriptor instead.
+func (*SignIdentifyRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignIdentifyRound2Message) GetAccused() []uint32 {
+	if x != nil {
+		return x.Accused
+	}
+	return nil
+}
+
+// Represents a message of a batch signing session: the messages of the same round of each of its signing sessions,
+// sent to the same parties.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the wire bytes of the message of each session, in the order of the messages being signed
+	Messages [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignBatchMessage) GetMessages() [][]byte {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
@@ -653,7 +1112,7 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x57, 0x63, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
 	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65,
 	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x22,
-	0x99, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
+	0xd9, 0x03, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
 	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
 	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65,
 	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72,
@@ -662,7 +1121,27 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18,
 	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68,
 	0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x33, 0x0a, 0x11, 0x53,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x26, 0x0a, 0x0f, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x47, 0x61, 0x6d,
+	0x6d, 0x61, 0x58, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x67,
+	0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x6f, 0x72, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x59, 0x12, 0x31, 0x0a, 0x15, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x5f, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x61, 0x64, 0x61, 0x70,
+	0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x31,
+	0x0a, 0x15, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61,
+	0x59, 0x12, 0x2f, 0x0a, 0x14, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x11, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74,
+	0x61, 0x58, 0x12, 0x2f, 0x0a, 0x14, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x11, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65,
+	0x74, 0x61, 0x59, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x33, 0x0a, 0x11, 0x53,
 	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
@@ -691,10 +1170,57 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d,
 	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
 	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
+	0x74, 0x22, 0x57, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x6c, 0x12, 0x26, 0x0a, 0x0f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61, 0x64, 0x61,
+	0x70, 0x74, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x45, 0x0a, 0x18, 0x53, 0x69,
+	0x67, 0x6e, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x29, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x70, 0x64, 0x6c, 0x5f, 0x77, 0x5f, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x64, 0x6c, 0x57, 0x53, 0x6c, 0x61, 0x63,
+	0x6b, 0x22, 0xcc, 0x02, 0x0a, 0x18, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67,
+	0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x16,
+	0x0a, 0x07, 0x62, 0x61, 0x72, 0x5f, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x62, 0x61, 0x72, 0x52, 0x58, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x61, 0x72, 0x5f, 0x72, 0x5f,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x61, 0x72, 0x52, 0x59, 0x12, 0x0f,
+	0x0a, 0x03, 0x73, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x58, 0x12,
+	0x0f, 0x0a, 0x03, 0x73, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x59,
+	0x12, 0x0f, 0x0a, 0x03, 0x74, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x74,
+	0x58, 0x12, 0x0f, 0x0a, 0x03, 0x74, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
+	0x74, 0x59, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x5f, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61, 0x58, 0x12, 0x20, 0x0a, 0x0c,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x79, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x65, 0x74, 0x61, 0x59, 0x12, 0x17,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x5f, 0x75, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x55,
+	0x22, 0xbc, 0x01, 0x0a, 0x19, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x79, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c,
+	0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12, 0x21, 0x0a, 0x0c,
+	0x6b, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0b, 0x6b, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x67, 0x61, 0x6d, 0x6d, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x6f, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x72, 0x68, 0x6f, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x01, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x61, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61, 0x12, 0x11, 0x0a, 0x04, 0x6e, 0x75, 0x5f,
+	0x78, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x6e, 0x75, 0x58, 0x12, 0x11, 0x0a, 0x04,
+	0x6e, 0x75, 0x5f, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x6e, 0x75, 0x59, 0x22,
+	0x35, 0x0a, 0x19, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x79, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x63, 0x63, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x61,
+	0x63, 0x63, 0x75, 0x73, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f,
+	0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -709,18 +1235,23 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
-	(*SignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
-	(*SignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
-	(*SignRound2Message)(nil),  // 2: binance.tsslib.ecdsa.signing.SignRound2Message
-	(*SignRound3Message)(nil),  // 3: binance.tsslib.ecdsa.signing.SignRound3Message
-	(*SignRound4Message)(nil),  // 4: binance.tsslib.ecdsa.signing.SignRound4Message
-	(*SignRound5Message)(nil),  // 5: binance.tsslib.ecdsa.signing.SignRound5Message
-	(*SignRound6Message)(nil),  // 6: binance.tsslib.ecdsa.signing.SignRound6Message
-	(*SignRound7Message)(nil),  // 7: binance.tsslib.ecdsa.signing.SignRound7Message
-	(*SignRound8Message)(nil),  // 8: binance.tsslib.ecdsa.signing.SignRound8Message
-	(*SignRound9Message)(nil),  // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignRound1Message1)(nil),        // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
+	(*SignRound1Message2)(nil),        // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
+	(*SignRound2Message)(nil),         // 2: binance.tsslib.ecdsa.signing.SignRound2Message
+	(*SignRound3Message)(nil),         // 3: binance.tsslib.ecdsa.signing.SignRound3Message
+	(*SignRound4Message)(nil),         // 4: binance.tsslib.ecdsa.signing.SignRound4Message
+	(*SignRound5Message)(nil),         // 5: binance.tsslib.ecdsa.signing.SignRound5Message
+	(*SignRound6Message)(nil),         // 6: binance.tsslib.ecdsa.signing.SignRound6Message
+	(*SignRound7Message)(nil),         // 7: binance.tsslib.ecdsa.signing.SignRound7Message
+	(*SignRound8Message)(nil),         // 8: binance.tsslib.ecdsa.signing.SignRound8Message
+	(*SignRound9Message)(nil),         // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignPresignCheckMessage1)(nil),  // 10: binance.tsslib.ecdsa.signing.SignPresignCheckMessage1
+	(*SignPresignCheckMessage2)(nil),  // 11: binance.tsslib.ecdsa.signing.SignPresignCheckMessage2
+	(*SignIdentifyRound1Message)(nil), // 12: binance.tsslib.ecdsa.signing.SignIdentifyRound1Message
+	(*SignIdentifyRound2Message)(nil), // 13: binance.tsslib.ecdsa.signing.SignIdentifyRound2Message
+	(*SignBatchMessage)(nil),          // 14: binance.tsslib.ecdsa.signing.SignBatchMessage
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -856,6 +1387,66 @@ func file_protob_ecdsa_signing_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignPresignCheckMessage1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignPresignCheckMessage2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignIdentifyRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignIdentifyRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -863,7 +1454,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
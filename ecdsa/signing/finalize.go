@@ -10,9 +10,9 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -24,6 +24,22 @@ func (round *finalization) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	// check each s_j before aggregating, so that an invalid one is attributed to its sender
+	if round.temp.bigVi != nil {
+		if culprits := round.verifyPartialSignatures(); len(culprits) > 0 {
+			return round.WrapError(errors.New("partial signature verification failed"), culprits...)
+		}
+	} else if round.temp.presignSs != nil {
+		if culprits := round.verifyPresignedPartialSignatures(); len(culprits) > 0 {
+			return round.WrapError(errors.New("partial signature verification failed"), culprits...)
+		}
+	}
+	if round.temp.adaptor != nil {
+		if culprits := round.verifyAdaptorProofs(); len(culprits) > 0 {
+			return round.WrapError(errors.New("adaptor proof verification failed"), culprits...)
+		}
+	}
+
 	sumS := round.temp.si
 	modN := common.ModInt(round.Params().EC().Params().N)
 
@@ -36,32 +52,22 @@ func (round *finalization) Start() *tss.Error {
 		sumS = modN.Add(sumS, r9msg.UnmarshalS())
 	}
 
-	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
-		recid = 2
-	}
-	if round.temp.ry.Bit(0) != 0 {
-		recid |= 1
+	if round.temp.adaptor != nil {
+		// the pre-signature is output as is; the completed signature is normalized
+		return round.finalizeAdaptor(sumS)
 	}
 
-	// This is copied from:
-	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
-	// This is needed because of tendermint checks here:
-	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
-		recid ^= 1
-	}
+	sumS, recid := normalizeS(round.Params().EC(), sumS, recoveryID(round.temp.bigR), round.Params().LowS())
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
-	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
+	orderBytes := (round.Params().EC().Params().N.BitLen() + 7) / 8
+	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), orderBytes)
+	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), orderBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
-	round.data.SignatureRecovery = []byte{byte(recid)}
-	if round.temp.fullBytesLen == 0 {
+	round.data.SignatureRecovery = []byte{recid}
+	if round.temp.digest != nil {
+		round.data.M = round.temp.digest
+	} else if round.temp.fullBytesLen == 0 {
 		round.data.M = round.temp.m.Bytes()
 	} else {
 		var mBytes = make([]byte, round.temp.fullBytesLen)
@@ -99,6 +105,55 @@ func (round *finalization) NextRound() tss.Round {
 	return nil // finished!
 }
 
+// verifyPartialSignatures returns the parties whose s_j does not match their commitment V_j = R^s_j * g^l_j, this party
+// included so that every signer reports the same culprits. Phase 5 checked that the V_j commit to a valid signature,
+// so the sum of the s_j verifies when every s_j matches its V_j.
+func (round *finalization) verifyPartialSignatures() []*tss.PartyID {
+	ec := round.Params().EC()
+	N := ec.Params().N
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		sj, lj := r9msg.UnmarshalS(), r9msg.UnmarshalL()
+		if sj.Cmp(N) >= 0 || lj.Sign() == 0 || lj.Cmp(N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		VX, VY := ec.ScalarMult(round.temp.bigR.X(), round.temp.bigR.Y(), sj.Bytes())
+		gToLX, gToLY := ec.ScalarBaseMult(lj.Bytes())
+		VX, VY = ec.Add(VX, VY, gToLX, gToLY)
+		if !round.temp.bigVs[j].Equals(crypto.NewECPointNoCurveCheck(ec, VX, VY)) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
+// verifyPresignedPartialSignatures returns the parties whose s_j = m * k_j + r * sigma_j does not satisfy
+// s_j * R = m * (k_j * R) + r * (sigma_j * R), with the values checked when presigning. These sum to G and y, so the sum
+// of the s_j verifies when every s_j does.
+func (round *finalization) verifyPresignedPartialSignatures() []*tss.PartyID {
+	ec := round.Params().EC()
+	N := ec.Params().N
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		sj, barRj, Sj := r9msg.UnmarshalS(), round.temp.presignBarRs[j], round.temp.presignSs[j]
+		if sj.Cmp(N) >= 0 || barRj == nil || Sj == nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sRX, sRY := ec.ScalarMult(round.temp.bigR.X(), round.temp.bigR.Y(), sj.Bytes())
+		mBarRX, mBarRY := ec.ScalarMult(barRj.X(), barRj.Y(), round.temp.m.Bytes())
+		rSX, rSY := ec.ScalarMult(Sj.X(), Sj.Y(), round.temp.rx.Bytes())
+		expectedX, expectedY := ec.Add(mBarRX, mBarRY, rSX, rSY)
+		if sRX.Cmp(expectedX) != 0 || sRY.Cmp(expectedY) != 0 {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
 func padToLengthBytesInPlace(src []byte, length int) []byte {
 	oriLen := len(src)
 	if oriLen < length {
@@ -0,0 +1,87 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// DeriveChildPublicKey derives the child of the threshold key at the BIP-32 `path` with the chain code saved by keygen,
+// e.g. to show an address without signing. `version` is the 4-byte version of the extended key; nil selects the
+// bitcoin mainnet xpub version. Only non-hardened derivation is possible, as no party holds the private key.
+func DeriveChildPublicKey(key keygen.LocalPartySaveData, path []uint32, version []byte) (*ckd.ExtendedKey, error) {
+	_, child, err := deriveChildKey(key, path, version)
+	return child, err
+}
+
+// NewLocalPartyWithDerivationPath returns a party signing `digest` with the child of the threshold key at the BIP-32
+// `path`, see DeriveChildPublicKey. The key derivation delta is computed and applied to a copy of `key`, so the save
+// data is left unchanged. SignatureData.PublicKey and ExtendedPublicKey report the child key that the signature verifies
+// against; the extended key has the 4-byte `version`, and nil selects the bitcoin mainnet xpub version.
+func NewLocalPartyWithDerivationPath(
+	digest []byte,
+	path []uint32,
+	version []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	if len(digest) == 0 {
+		return nil, errors.New("signing.NewLocalPartyWithDerivationPath expected a non-empty digest")
+	}
+	delta, child, err := deriveChildKey(key, path, version)
+	if err != nil {
+		return nil, err
+	}
+	childKey := key
+	childKey.BigXj = append([]*crypto.ECPoint{}, key.BigXj...)
+	keys := []keygen.LocalPartySaveData{childKey}
+	if err := UpdatePublicKeyAndAdjustBigXj(delta, keys, &child.PublicKey, params.EC()); err != nil {
+		return nil, err
+	}
+
+	p := NewLocalPartyWithKDD(digestToInt(digest, params.EC()), params, keys[0], delta, out, end).(*LocalParty)
+	p.temp.digest = append([]byte{}, digest...)
+	p.data.PublicKey = elliptic.MarshalCompressed(params.EC(), child.X, child.Y)
+	p.data.ExtendedPublicKey = child.String()
+	return p, nil
+}
+
+// ----- //
+
+func deriveChildKey(key keygen.LocalPartySaveData, path []uint32, version []byte) (*big.Int, *ckd.ExtendedKey, error) {
+	if key.ECDSAPub == nil {
+		return nil, nil, errors.New("the save data has no ECDSAPub")
+	}
+	if len(key.ChainCode) != keygen.ChainCodeLen {
+		return nil, nil, errors.New("the save data has no chain code; the key was generated before keygen produced one")
+	}
+	for _, index := range path {
+		if index >= ckd.HardenedKeyStart {
+			return nil, nil, fmt.Errorf("cannot derive the hardened index %d of a threshold key", index-ckd.HardenedKeyStart)
+		}
+	}
+	if version == nil {
+		version = chaincfg.MainNetParams.HDPublicKeyID[:]
+	}
+	if len(version) != 4 {
+		return nil, nil, errors.New("the extended key version must be 4 bytes")
+	}
+	return deriveChildPublicKey(key.ECDSAPub, key.ChainCode, path, version, key.ECDSAPub.Curve())
+}
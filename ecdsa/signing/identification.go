@@ -0,0 +1,461 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// When the phase 5 check U = T fails, the signers run an identification protocol instead of simply aborting, so that a
+// cheater cannot cause unattributable failures. Everyone ends with the same culprits in the returned *tss.Error.
+//
+// s_i was not revealed, so the nonce shares of this session can be given away: each party reveals k_i, gamma_i, rho_i,
+// l_i and its shares as Bob in the MtAs, with g^nu instead of nu for the MtAwc so that w_i stays secret.
+// The receivers of the private MtA messages check them against what they received and accuse the senders of
+// inconsistent values in a second round. The other checks only use broadcast values.
+//
+// The parties cannot tell which of two parties lies about a private message between them, so both the accuser and
+// the accused are reported in that case.
+//
+// Once U = T holds, each s_j is revealed with l_j and checked against its commitment V_j = R^s_j * g^l_j in the
+// finalization, see verifyPartialSignatures.
+//
+// Presigning has no phase 5. The same protocol identifies the cheaters when the check of GG20 section 4.3 fails, i.e.
+// sum(k_j * R) != G or sum(S_j) != y, with the commitments T_j = sigma_j * G + l_j * R in place of V_j.
+
+type identifyStage int
+
+const (
+	identifyNone identifyStage = iota
+	// the phase 5 check failed, before s_i was revealed
+	identifyAfterPhase5
+	// the presignature check failed, before the presignature was output
+	identifyAfterPresign
+)
+
+type identifyReveal struct {
+	k, gamma, rho, l *big.Int
+	kRandomness      []*big.Int
+	betas            []*big.Int
+	nus              []*crypto.ECPoint
+}
+
+func (round *identification1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 11
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	nus := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	for j := range nus {
+		if j == i {
+			continue
+		}
+		nus[j] = crypto.ScalarBaseMult(round.Params().EC(), round.temp.vs[j])
+	}
+	rho := round.temp.roi
+	if rho == nil {
+		rho = zero // not sampled when presigning
+	}
+	r1msg := NewSignIdentifyRound1Message(round.PartyID(),
+		round.temp.k, round.temp.cRandomness, round.temp.gamma, rho, round.temp.li, round.temp.betas, nus)
+	round.temp.signIdentifyRound1Messages[round.PartyID().Index] = r1msg
+	round.out <- r1msg
+	return nil
+}
+
+func (round *identification1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signIdentifyRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *identification1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignIdentifyRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *identification1) NextRound() tss.Round {
+	round.started = false
+	return &identification2{round}
+}
+
+// ----- //
+
+func (round *identification2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 12
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+	i := round.PartyID().Index
+	accused := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		reveal, err := round.reveal(j)
+		if err != nil || !crypto.ScalarBaseMult(ec, reveal.gamma).Equals(round.temp.bigGammas[j]) {
+			continue // publicly identified in the next round
+		}
+		// k_j must be what was encrypted in the MtA with this party
+		r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
+		cA, err := round.key.PaillierPKs[j].EncryptWithRandomness(reveal.k, reveal.kRandomness[i])
+		if err != nil || cA.Cmp(r1msg.UnmarshalC()) != 0 {
+			accused = append(accused, Pj)
+			continue
+		}
+		// alpha_ij + beta_ji = k_i * gamma_j
+		if modN.Add(round.temp.alphas[j], reveal.betas[i]).Cmp(modN.Mul(round.temp.k, reveal.gamma)) != 0 {
+			accused = append(accused, Pj)
+			continue
+		}
+		// g^u_ij * g^nu_ji = W_j^k_i
+		uX, uY := ec.ScalarBaseMult(round.temp.us[j].Bytes())
+		uX, uY = ec.Add(uX, uY, reveal.nus[i].X(), reveal.nus[i].Y())
+		if !crypto.NewECPointNoCurveCheck(ec, uX, uY).Equals(round.temp.bigWs[j].ScalarMult(round.temp.k)) {
+			accused = append(accused, Pj)
+		}
+	}
+
+	r2msg := NewSignIdentifyRound2Message(round.PartyID(), accused)
+	round.temp.signIdentifyRound2Messages[i] = r2msg
+	round.out <- r2msg
+	return nil
+}
+
+func (round *identification2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signIdentifyRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *identification2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignIdentifyRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *identification2) NextRound() tss.Round {
+	round.started = false
+	return &identificationEnd{round}
+}
+
+// ----- //
+
+func (round *identificationEnd) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 13
+	round.started = true
+	round.resetOK()
+
+	if round.temp.identifyStage == identifyAfterPresign {
+		return round.identifyAfterPresign()
+	}
+	return round.identifyAfterPhase5()
+}
+
+// identifyAfterPhase5 checks the revealed values in turn, reporting the parties that fail the first check failed by anyone
+func (round *identificationEnd) identifyAfterPhase5() *tss.Error {
+	ec := round.Params().EC()
+	q := ec.Params().N
+	modN := common.ModInt(q)
+	Ps := round.Parties().IDs()
+	reveals := make([]*identifyReveal, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+
+	// 1. the revealed values must match the broadcast commitments
+	for j, Pj := range Ps {
+		reveal, err := round.reveal(j)
+		if err != nil ||
+			!crypto.ScalarBaseMult(ec, reveal.gamma).Equals(round.temp.bigGammas[j]) ||
+			!crypto.ScalarBaseMult(ec, reveal.rho).Equals(round.temp.bigAs[j]) ||
+			!round.temp.bigV.ScalarMult(reveal.rho).Equals(round.temp.bigUs[j]) ||
+			!round.temp.bigA.ScalarMult(reveal.l).Equals(round.temp.bigTs[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		reveals[j] = reveal
+	}
+	if len(culprits) > 0 {
+		return round.identified(errors.New("U doesn't equal T: revealed values do not match their commitments"), culprits)
+	}
+
+	// 2. accusations about the private MtA messages; the accuser and the accused are both reported
+	if culprits = round.identifyAccusations(); len(culprits) > 0 {
+		return round.identified(errors.New("U doesn't equal T: parties disagree about their MtA messages"), culprits)
+	}
+
+	// 3. delta_j = k_j * gamma_j + sum(alpha_jl + beta_jl), with alpha_jl = k_j * gamma_l - beta_lj
+	k, culprits := round.identifyDeltas(reveals)
+	if len(culprits) > 0 {
+		return round.identified(errors.New("U doesn't equal T: a revealed delta_j is inconsistent"), culprits)
+	}
+
+	// 4. R = g^(k^-1) now, and V_j = R^s_j * g^l_j with s_j = m * k_j + r * sigma_j, where
+	// g^sigma_j = W^k_j * sum(g^nu_jl - g^nu_lj) is known from the MtAwc shares. Checking
+	// V_j * (sum g^nu_lj)^c = g^l_j * R^(m * k_j) * W^(c * k_j) * (sum g^nu_jl)^c with c = r / k
+	if k.Sign() == 0 {
+		return round.identified(errors.New("U doesn't equal T: the revealed k is zero"), nil)
+	}
+	c := modN.Mul(round.temp.rx, modN.ModInverse(k))
+	WX, WY := round.sumW()
+	for j, Pj := range Ps {
+		rj := reveals[j]
+		nuInX, nuInY, nuOutX, nuOutY := round.nuSums(reveals, j)
+		lhsX, lhsY := ec.ScalarMult(nuInX, nuInY, c.Bytes())
+		lhsX, lhsY = ec.Add(lhsX, lhsY, round.temp.bigVs[j].X(), round.temp.bigVs[j].Y())
+
+		rhsX, rhsY := ec.ScalarBaseMult(rj.l.Bytes())
+		x, y := ec.ScalarMult(round.temp.bigR.X(), round.temp.bigR.Y(), modN.Mul(round.temp.m, rj.k).Bytes())
+		rhsX, rhsY = ec.Add(rhsX, rhsY, x, y)
+		x, y = ec.ScalarMult(WX, WY, modN.Mul(c, rj.k).Bytes())
+		rhsX, rhsY = ec.Add(rhsX, rhsY, x, y)
+		x, y = ec.ScalarMult(nuOutX, nuOutY, c.Bytes())
+		rhsX, rhsY = ec.Add(rhsX, rhsY, x, y)
+
+		if lhsX.Cmp(rhsX) != 0 || lhsY.Cmp(rhsY) != 0 {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return round.identified(errors.New("U doesn't equal T: a V_j does not commit to a valid s_j"), culprits)
+}
+
+// identifyAfterPresign checks the revealed values in turn like identifyAfterPhase5, with the presignature check values
+func (round *identificationEnd) identifyAfterPresign() *tss.Error {
+	ec := round.Params().EC()
+	Ps := round.Parties().IDs()
+	reveals := make([]*identifyReveal, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+
+	// 1. the revealed values must match the broadcast values
+	for j, Pj := range Ps {
+		reveal, err := round.reveal(j)
+		if err != nil ||
+			!crypto.ScalarBaseMult(ec, reveal.gamma).Equals(round.temp.bigGammas[j]) ||
+			!round.temp.bigR.ScalarMult(reveal.k).Equals(round.temp.presignBarRs[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		reveals[j] = reveal
+	}
+	if len(culprits) > 0 {
+		return round.identified(errors.New("the presignature check failed: revealed values do not match their commitments"), culprits)
+	}
+
+	// 2. accusations about the private MtA messages; the accuser and the accused are both reported
+	if culprits = round.identifyAccusations(); len(culprits) > 0 {
+		return round.identified(errors.New("the presignature check failed: parties disagree about their MtA messages"), culprits)
+	}
+
+	// 3. delta_j = k_j * gamma_j + sum(alpha_jl + beta_jl), so that R = k^-1 * G and sum(k_j * R) = G
+	if _, culprits = round.identifyDeltas(reveals); len(culprits) > 0 {
+		return round.identified(errors.New("the presignature check failed: a revealed delta_j is inconsistent"), culprits)
+	}
+
+	// 4. T_j = g^sigma_j * R^l_j, where g^sigma_j = W^k_j * sum(g^nu_jl - g^nu_lj) is known from the MtAwc shares.
+	// The proof of S_j then makes sum(S_j) = k * x * R = y. Checking
+	// T_j * sum(g^nu_lj) = R^l_j * W^k_j * sum(g^nu_jl)
+	WX, WY := round.sumW()
+	for j, Pj := range Ps {
+		rj := reveals[j]
+		nuInX, nuInY, nuOutX, nuOutY := round.nuSums(reveals, j)
+		lhsX, lhsY := ec.Add(round.temp.presignTs[j].X(), round.temp.presignTs[j].Y(), nuInX, nuInY)
+
+		rhsX, rhsY := ec.ScalarMult(round.temp.bigR.X(), round.temp.bigR.Y(), rj.l.Bytes())
+		x, y := ec.ScalarMult(WX, WY, rj.k.Bytes())
+		rhsX, rhsY = ec.Add(rhsX, rhsY, x, y)
+		rhsX, rhsY = ec.Add(rhsX, rhsY, nuOutX, nuOutY)
+
+		if lhsX.Cmp(rhsX) != 0 || lhsY.Cmp(rhsY) != 0 {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return round.identified(errors.New("the presignature check failed: a T_j does not commit to a valid sigma_j"), culprits)
+}
+
+// identifyAccusations returns the parties that were accused of sending inconsistent private MtA messages, with their
+// accusers, and the parties whose accusations are malformed
+func (round *identificationEnd) identifyAccusations() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	accusedBy := make([]bool, len(Ps))
+	for j, Pj := range Ps {
+		r2msg := round.temp.signIdentifyRound2Messages[j].Content().(*SignIdentifyRound2Message)
+		accused, err := r2msg.UnmarshalAccused(len(Ps))
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		for _, a := range accused {
+			accusedBy[a] = true
+		}
+		if len(accused) > 0 {
+			accusedBy[j] = true
+		}
+	}
+	for j, Pj := range Ps {
+		if accusedBy[j] && !containsParty(culprits, Pj) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
+// identifyDeltas returns the sum k of the revealed k_j and the parties whose delta_j of round 3 is not
+// k_j * gamma_j + sum(alpha_jl + beta_jl), with alpha_jl = k_j * gamma_l - beta_lj
+func (round *identificationEnd) identifyDeltas(reveals []*identifyReveal) (*big.Int, []*tss.PartyID) {
+	modN := common.ModInt(round.Params().EC().Params().N)
+	Ps := round.Parties().IDs()
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	k := big.NewInt(0)
+	for j, Pj := range Ps {
+		rj := reveals[j]
+		k = modN.Add(k, rj.k)
+		deltaJ := modN.Mul(rj.k, rj.gamma)
+		for l := range Ps {
+			if l == j {
+				continue
+			}
+			deltaJ = modN.Add(deltaJ, modN.Sub(modN.Mul(rj.k, reveals[l].gamma), reveals[l].betas[j]))
+			deltaJ = modN.Add(deltaJ, rj.betas[l])
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		if deltaJ.Cmp(new(big.Int).SetBytes(r3msg.GetTheta())) != 0 {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return k, culprits
+}
+
+// sumW returns W = sum(W_j), the public key of the shares w_j
+func (round *identificationEnd) sumW() (*big.Int, *big.Int) {
+	ec := round.Params().EC()
+	WX, WY := round.temp.bigWs[0].X(), round.temp.bigWs[0].Y()
+	for _, bigWj := range round.temp.bigWs[1:] {
+		WX, WY = ec.Add(WX, WY, bigWj.X(), bigWj.Y())
+	}
+	return WX, WY
+}
+
+// nuSums returns sum(g^nu_lj) and sum(g^nu_jl) over the other parties l, from the revealed MtAwc shares
+func (round *identificationEnd) nuSums(reveals []*identifyReveal, j int) (nuInX, nuInY, nuOutX, nuOutY *big.Int) {
+	ec := round.Params().EC()
+	for l := range reveals {
+		if l == j {
+			continue
+		}
+		if nuInX == nil {
+			nuInX, nuInY = reveals[l].nus[j].X(), reveals[l].nus[j].Y()
+			nuOutX, nuOutY = reveals[j].nus[l].X(), reveals[j].nus[l].Y()
+			continue
+		}
+		nuInX, nuInY = ec.Add(nuInX, nuInY, reveals[l].nus[j].X(), reveals[l].nus[j].Y())
+		nuOutX, nuOutY = ec.Add(nuOutX, nuOutY, reveals[j].nus[l].X(), reveals[j].nus[l].Y())
+	}
+	return
+}
+
+func (round *identificationEnd) identified(err error, culprits []*tss.PartyID) *tss.Error {
+	// clear temp.k from memory, lint ignore
+	round.temp.k = zero
+	if len(culprits) == 0 {
+		return round.WrapError(fmt.Errorf("%v, and the cheaters could not be identified", err))
+	}
+	return round.WrapError(err, culprits...)
+}
+
+func (round *identificationEnd) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *identificationEnd) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *identificationEnd) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// reveal parses and range-checks the values revealed by party j after a failed phase 5 or presignature check
+func (round *base) reveal(j int) (*identifyReveal, error) {
+	ec := round.Params().EC()
+	q := ec.Params().N
+	partyCount := len(round.Parties().IDs())
+	r1msg := round.temp.signIdentifyRound1Messages[j].Content().(*SignIdentifyRound1Message)
+	if len(r1msg.GetBeta()) != partyCount {
+		return nil, errors.New("the nonce shares were not revealed")
+	}
+	reveal := &identifyReveal{
+		k:           r1msg.UnmarshalK(),
+		gamma:       r1msg.UnmarshalGamma(),
+		rho:         r1msg.UnmarshalRho(),
+		l:           r1msg.UnmarshalL(),
+		kRandomness: r1msg.UnmarshalKRandomness(),
+		betas:       r1msg.UnmarshalBetas(),
+	}
+	for _, v := range append([]*big.Int{reveal.k, reveal.gamma, reveal.rho, reveal.l}, reveal.betas...) {
+		if v.Cmp(q) >= 0 {
+			return nil, errors.New("a revealed value is out of range")
+		}
+	}
+	nus, err := r1msg.UnmarshalNus(ec, j)
+	if err != nil {
+		return nil, err
+	}
+	reveal.nus = nus
+	return reveal, nil
+}
+
+func containsParty(parties []*tss.PartyID, party *tss.PartyID) bool {
+	for _, p := range parties {
+		if p.Index == party.Index {
+			return true
+		}
+	}
+	return false
+}
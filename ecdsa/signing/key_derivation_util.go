@@ -38,6 +38,10 @@ func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.Lo
 }
 
 func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, ec elliptic.Curve) (*big.Int, *ckd.ExtendedKey, error) {
+	return deriveChildPublicKey(masterPub, chainCode, path, chaincfg.MainNetParams.HDPublicKeyID[:], ec)
+}
+
+func deriveChildPublicKey(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, version []byte, ec elliptic.Curve) (*big.Int, *ckd.ExtendedKey, error) {
 	// build ecdsa key pair
 	pk := ecdsa.PublicKey{
 		Curve: ec,
@@ -45,14 +49,13 @@ func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []
 		Y:     masterPub.Y(),
 	}
 
-	net := &chaincfg.MainNetParams
 	extendedParentPk := &ckd.ExtendedKey{
 		PublicKey:  pk,
 		Depth:      0,
 		ChildIndex: 0,
 		ChainCode:  chainCode[:],
 		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
-		Version:    net.HDPrivateKeyID[:],
+		Version:    version,
 	}
 
 	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
@@ -35,6 +35,9 @@ type (
 		temp localTempData
 		data *common.SignatureData
 
+		// signing with a presignature: only the online round is run
+		preSignature *PreSignatureData
+
 		// outbound messaging
 		out chan<- tss.Message
 		end chan<- *common.SignatureData
@@ -50,7 +53,11 @@ type (
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signPresignCheckMessage1s,
+		signPresignCheckMessage2s,
+		signIdentifyRound1Messages,
+		signIdentifyRound2Messages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -66,7 +73,9 @@ type (
 		keyDerivationDelta,
 		gamma *big.Int
 		fullBytesLen int
+		digest       []byte // given to NewLocalPartyWithDigest, output as is in SignatureData.M
 		cis          []*big.Int
+		cRandomness  []*big.Int // the Paillier randomness of cis
 		bigWs        []*crypto.ECPoint
 		pointGamma   *crypto.ECPoint
 		deCommit     cmt.HashDeCommitment
@@ -79,6 +88,14 @@ type (
 		pi1jis []*mta.ProofBob
 		pi2jis []*mta.ProofBobWC
 
+		// round 3
+		alphas,
+		us []*big.Int // return values of Alice_end and Alice_end_wc
+
+		// round 4
+		bigGammas,
+		adaptorGammas []*crypto.ECPoint // gamma_j * Y when signing with an adaptor point
+
 		// round 5
 		li,
 		si,
@@ -89,17 +106,53 @@ type (
 		bigAi,
 		bigVi *crypto.ECPoint
 		DPower cmt.HashDeCommitment
+		// adaptor signing: R_Y = R^y, the nonce share t_i and the commitments t_i * G and t_i * Y of the DLEQ proof
+		bigRY    *crypto.ECPoint
+		adaptorT *big.Int
+		adaptorAi,
+		adaptorBi *crypto.ECPoint
 
 		// round 7
 		Ui,
-		Ti *crypto.ECPoint
+		Ti,
+		bigV,
+		bigA *crypto.ECPoint
+		bigVs,
+		bigAs []*crypto.ECPoint
 		DTelda cmt.HashDeCommitment
+		// adaptor signing: the commitments of every party to its DLEQ nonce share, their sums and the challenge
+		adaptorAs,
+		adaptorBs []*crypto.ECPoint
+		adaptorA,
+		adaptorB *crypto.ECPoint
+		adaptorC *big.Int
+
+		// round 9
+		bigUs,
+		bigTs []*crypto.ECPoint
+
+		// identification of the cheaters once the phase 5 checks or the signature verification failed
+		identifyStage identifyStage
 
 		ssidNonce *big.Int
 		ssid      []byte
+
+		// presigning: receives the presignature after round 4 instead of continuing with the message
+		presignEnd chan<- *PreSignatureData
+		// presigning: k_j * R, S_j = sigma_j * R and T_j = sigma_j * G + l_j * R of every party
+		presignBarRs,
+		presignSs,
+		presignTs []*crypto.ECPoint
+
+		// adaptor signing: receives the pre-signature locked to the adaptor point instead of a signature
+		adaptor    *crypto.ECPoint
+		adaptorEnd chan<- *AdaptorSignatureData
 	}
 )
 
+// NewLocalParty returns a party signing `msg`, the hash of the message as an integer less than the curve order.
+// SignatureData.M holds msg.Bytes(), without leading zero bytes unless their number is given in `fullBytesLen`;
+// prefer NewLocalPartyWithDigest, which takes the digest as bytes.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -124,23 +177,14 @@ func NewLocalPartyWithKDD(
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		keys:      keygen.BuildLocalSaveDataSubsetWithIndexes(key, params.ShareIndexes()),
 		temp:      localTempData{},
 		data:      &common.SignatureData{},
 		out:       out,
 		end:       end,
 	}
 	// msgs init
-	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound4Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound5Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound6Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
-	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.localMessageStore = newLocalMessageStore(partyCount)
 	// temp data init
 	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
@@ -150,6 +194,7 @@ func NewLocalPartyWithKDD(
 		p.temp.fullBytesLen = 0
 	}
 	p.temp.cis = make([]*big.Int, partyCount)
+	p.temp.cRandomness = make([]*big.Int, partyCount)
 	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
 	p.temp.betas = make([]*big.Int, partyCount)
 	p.temp.c1jis = make([]*big.Int, partyCount)
@@ -157,15 +202,49 @@ func NewLocalPartyWithKDD(
 	p.temp.pi1jis = make([]*mta.ProofBob, partyCount)
 	p.temp.pi2jis = make([]*mta.ProofBobWC, partyCount)
 	p.temp.vs = make([]*big.Int, partyCount)
+	p.temp.alphas = make([]*big.Int, partyCount)
+	p.temp.us = make([]*big.Int, partyCount)
+	p.temp.bigGammas = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigVs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigAs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigUs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigTs = make([]*crypto.ECPoint, partyCount)
 	return p
 }
 
+func newLocalMessageStore(partyCount int) localMessageStore {
+	return localMessageStore{
+		signRound1Message1s: make([]tss.ParsedMessage, partyCount),
+		signRound1Message2s: make([]tss.ParsedMessage, partyCount),
+		signRound2Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound3Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound4Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound5Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound6Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound7Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound8Messages:  make([]tss.ParsedMessage, partyCount),
+		signRound9Messages:  make([]tss.ParsedMessage, partyCount),
+
+		signPresignCheckMessage1s: make([]tss.ParsedMessage, partyCount),
+		signPresignCheckMessage2s: make([]tss.ParsedMessage, partyCount),
+
+		signIdentifyRound1Messages: make([]tss.ParsedMessage, partyCount),
+		signIdentifyRound2Messages: make([]tss.ParsedMessage, partyCount),
+	}
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
+	if p.preSignature != nil {
+		return newOnlineRound(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	}
 	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
 }
 
 func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if p.preSignature != nil {
+			return nil // the presignature is checked by the online round
+		}
 		round1, ok := round.(*round1)
 		if !ok {
 			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
@@ -231,6 +310,14 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignPresignCheckMessage1:
+		p.temp.signPresignCheckMessage1s[fromPIdx] = msg
+	case *SignPresignCheckMessage2:
+		p.temp.signPresignCheckMessage2s[fromPIdx] = msg
+	case *SignIdentifyRound1Message:
+		p.temp.signIdentifyRound1Messages[fromPIdx] = msg
+	case *SignIdentifyRound2Message:
+		p.temp.signIdentifyRound2Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
@@ -7,20 +7,30 @@
 package signing
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	crypto2 "github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/tweak"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -357,3 +367,898 @@ func fillBytes(x *big.Int, buf []byte) []byte {
 	}
 	return buf
 }
+
+func TestE2EPresign(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+
+	// PHASE: presign
+	preSignatures := presignWithFixtures(t, signPIDs, keys, threshold)
+	for _, preSignature := range preSignatures {
+		assert.True(t, preSignature.R.Equals(preSignatures[0].R), "the signers should agree on R")
+		assert.Equal(t, preSignatures[0].SSID, preSignature.SSID)
+		assert.Equal(t, preSignatures[0].BarRs, preSignature.BarRs, "the signers should agree on each k_j * R")
+		assert.Equal(t, preSignatures[0].Ss, preSignature.Ss, "the signers should agree on each sigma_j * R")
+	}
+
+	// PHASE: online signing, a single round
+	msg := big.NewInt(42)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalPartyWithPreSignature(msg, params, preSignatures[i], outCh, endCh).(*LocalParty)
+		assert.Nil(t, preSignatures[i].KI, "the nonce share should be cleared once used")
+		assert.Nil(t, preSignatures[i].SigmaI, "the sigma share should be cleared once used")
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	var ended, sent int
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			sent++
+			assert.Nil(t, msg.GetTo(), "the online round should only broadcast")
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			pk := ecdsa.PublicKey{
+				Curve: tss.EC(),
+				X:     keys[0].ECDSAPub.X(),
+				Y:     keys[0].ECDSAPub.Y(),
+			}
+			ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			if ended++; ended == len(signPIDs) {
+				break signing
+			}
+		}
+	}
+	assert.Equal(t, len(signPIDs), sent, "each signer should send one message")
+}
+
+// presignWithFixtures runs the presign protocol between the signers and returns their presignatures
+func presignWithFixtures(t *testing.T, signPIDs tss.SortedPartyIDs, keys []keygen.LocalPartySaveData, threshold int) []*PreSignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+	preSignatures := make([]*PreSignatureData, len(signPIDs))
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	type indexedPreSignature struct {
+		index        int
+		preSignature *PreSignatureData
+	}
+	endCh := make(chan indexedPreSignature, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		partyEndCh := make(chan *PreSignatureData, 1)
+		P := NewPresignLocalParty(params, keys[i], outCh, partyEndCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(i int) {
+			endCh <- indexedPreSignature{i, <-partyEndCh}
+		}(i)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	var ended int
+presign:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break presign
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case indexed := <-endCh:
+			preSignatures[indexed.index] = indexed.preSignature
+			if ended++; ended == len(signPIDs) {
+				break presign
+			}
+		}
+	}
+	return preSignatures
+}
+
+func TestE2EPresignIdentifiableAbort(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	cheater := 0
+
+	tests := []struct {
+		name string
+		// tamper is called for each message before it is delivered
+		tamper func(cheater *LocalParty, msg tss.Message)
+		round  int
+	}{
+		{
+			// the cheater's k_i * R is not for the k_i it encrypted, so its proof fails
+			name: "bad nonce share",
+			tamper: func(cheater *LocalParty, msg tss.Message) {
+				if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound4Message); ok && msg.GetFrom().Index == cheater.PartyID().Index {
+					cheater.temp.k = new(big.Int).Add(cheater.temp.k, big.NewInt(1))
+				}
+			},
+			round: 6,
+		},
+		{
+			// the cheater's sigma_i is wrong, so sum(S_j) != y and the nonce shares are revealed
+			name: "bad sigma share",
+			tamper: func(cheater *LocalParty, msg tss.Message) {
+				if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound3Message); ok && msg.GetFrom().Index == cheater.PartyID().Index {
+					cheater.temp.sigma = new(big.Int).Add(cheater.temp.sigma, big.NewInt(1))
+				}
+			},
+			round: 13,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p2pCtx := tss.NewPeerContext(signPIDs)
+			parties := make([]*LocalParty, 0, len(signPIDs))
+			errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+			outCh := make(chan tss.Message, len(signPIDs))
+			endCh := make(chan *PreSignatureData, len(signPIDs))
+			updater := test.SharedPartyUpdater
+
+			for i := 0; i < len(signPIDs); i++ {
+				params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+				P := NewPresignLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+				parties = append(parties, P)
+				go func(P *LocalParty) {
+					if err := P.Start(); err != nil {
+						errCh <- err
+					}
+				}(P)
+			}
+
+			errs := make(map[int]*tss.Error, len(signPIDs))
+		presign:
+			for {
+				select {
+				case err := <-errCh:
+					// the cheater does not check its own proofs, so only the others are awaited
+					if err.Victim().Index != cheater {
+						errs[err.Victim().Index] = err
+					}
+					if len(errs) == len(signPIDs)-1 {
+						break presign
+					}
+
+				case msg := <-outCh:
+					tc.tamper(parties[cheater], msg)
+					dest := msg.GetTo()
+					if dest == nil {
+						for _, P := range parties {
+							if P.PartyID().Index == msg.GetFrom().Index {
+								continue
+							}
+							go updater(P, msg, errCh)
+						}
+					} else {
+						go updater(parties[dest[0].Index], msg, errCh)
+					}
+
+				case <-endCh:
+					assert.FailNow(t, "no presignature should be output")
+				}
+			}
+
+			for _, err := range errs {
+				assert.Equal(t, tc.round, err.Round(), err.Error())
+				if assert.Len(t, err.Culprits(), 1, err.Error()) {
+					assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+				}
+			}
+		})
+	}
+}
+
+func TestE2EPresignBadPartialSignature(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	preSignatures := presignWithFixtures(t, signPIDs, keys, threshold)
+	cheater := 0
+
+	msg := big.NewInt(42)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalPartyWithPreSignature(msg, params, preSignatures[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+			if len(errs) == len(signPIDs)-1 {
+				break signing
+			}
+
+		case msg := <-outCh:
+			if msg.GetFrom().Index == cheater {
+				// the others receive s_i + 1 instead of the cheater's share
+				si := msg.(tss.ParsedMessage).Content().(*SignRound9Message).UnmarshalS()
+				msg = NewSignRound9Message(msg.GetFrom(), new(big.Int).Add(si, big.NewInt(1)), nil, nil)
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-endCh:
+			// the cheater checks its own untampered share
+		}
+	}
+
+	for j, err := range errs {
+		assert.NotEqual(t, cheater, j, "the cheater should not fail")
+		assert.Contains(t, err.Error(), "partial signature verification failed")
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+		}
+	}
+}
+
+func TestE2EIdentifiableAbort(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	cheater := 0
+
+	tests := []struct {
+		name string
+		// tamper is called for each message before it is delivered
+		tamper func(cheater *LocalParty, msg tss.Message)
+		round  int
+	}{
+		{
+			// the cheater's sigma_i is wrong, so U != T and the nonce shares are revealed
+			name: "bad sigma share",
+			tamper: func(cheater *LocalParty, msg tss.Message) {
+				if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound3Message); ok && msg.GetFrom().Index == cheater.PartyID().Index {
+					cheater.temp.sigma = new(big.Int).Add(cheater.temp.sigma, big.NewInt(1))
+				}
+			},
+			round: 13,
+		},
+		{
+			// the cheater broadcasts an s_i that does not match V_i, which is caught before aggregating the s_i
+			name: "bad signature share",
+			tamper: func(cheater *LocalParty, msg tss.Message) {
+				if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound7Message); ok && msg.GetFrom().Index == cheater.PartyID().Index {
+					cheater.temp.si = new(big.Int).Add(cheater.temp.si, big.NewInt(1))
+				}
+			},
+			round: 10,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p2pCtx := tss.NewPeerContext(signPIDs)
+			parties := make([]*LocalParty, 0, len(signPIDs))
+			errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+			outCh := make(chan tss.Message, len(signPIDs))
+			endCh := make(chan *common.SignatureData, len(signPIDs))
+			updater := test.SharedPartyUpdater
+
+			for i := 0; i < len(signPIDs); i++ {
+				params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+				P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+				parties = append(parties, P)
+				go func(P *LocalParty) {
+					if err := P.Start(); err != nil {
+						errCh <- err
+					}
+				}(P)
+			}
+
+			errs := make(map[int]*tss.Error, len(signPIDs))
+		signing:
+			for {
+				select {
+				case err := <-errCh:
+					errs[err.Victim().Index] = err
+					if len(errs) == len(signPIDs) {
+						break signing
+					}
+
+				case msg := <-outCh:
+					tc.tamper(parties[cheater], msg)
+					dest := msg.GetTo()
+					if dest == nil {
+						for _, P := range parties {
+							if P.PartyID().Index == msg.GetFrom().Index {
+								continue
+							}
+							go updater(P, msg, errCh)
+						}
+					} else {
+						go updater(parties[dest[0].Index], msg, errCh)
+					}
+
+				case <-endCh:
+					assert.FailNow(t, "signing should not succeed")
+				}
+			}
+
+			for _, err := range errs {
+				assert.Equal(t, tc.round, err.Round(), err.Error())
+				if assert.Len(t, err.Culprits(), 1, err.Error()) {
+					assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+				}
+			}
+		})
+	}
+}
+
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), new(big.Int).SetBytes([]byte("batch"))}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*BatchLocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []*common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewBatchLocalParty(msgs, params, keys[i], outCh, endCh).(*BatchLocalParty)
+		parties = append(parties, P)
+		go func(P *BatchLocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended, sent int
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			sent++
+			assert.IsType(t, &SignBatchMessage{}, msg.(tss.ParsedMessage).Content())
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if !assert.Len(t, data, len(msgs)) {
+				return
+			}
+			pk := ecdsa.PublicKey{
+				Curve: tss.EC(),
+				X:     keys[0].ECDSAPub.X(),
+				Y:     keys[0].ECDSAPub.Y(),
+			}
+			for k, sig := range data {
+				assert.Equal(t, msgs[k].Bytes(), sig.M)
+				ok := ecdsa.Verify(&pk, msgs[k].Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+				assert.True(t, ok, "ecdsa verify must pass")
+			}
+			if ended++; ended == len(signPIDs) {
+				break signing
+			}
+		}
+	}
+	// as many messages as signing a single message: n-1 p2p messages in rounds 1 and 2, and a broadcast in the others and round 1
+	n := len(signPIDs)
+	assert.Equal(t, n*(2*(n-1)+8), sent, "the sessions should share their rounds")
+}
+
+func TestBatchCollectsSessionMessages(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(2, 2)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), 1)
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43)}
+	P := NewBatchLocalParty(msgs, params, keys[0], make(chan tss.Message), make(chan []*common.SignatureData)).(*BatchLocalParty)
+
+	// a session may send any number of messages within one update without blocking
+	const sends = 100
+	err2 := P.forEachSession(func(k int, session *LocalParty) *tss.Error {
+		for n := 0; n < sends; n++ {
+			P.sessionOut[k] <- NewSignRound9Message(session.PartyID(), big.NewInt(int64(n)), nil, nil)
+		}
+		return nil
+	})
+	assert.Nil(t, err2)
+	for k := range msgs {
+		if assert.Len(t, P.pending[k], sends) {
+			assert.Equal(t, big.NewInt(sends-1), P.pending[k][sends-1].(tss.ParsedMessage).Content().(*SignRound9Message).UnmarshalS())
+		}
+	}
+}
+
+// signWithFixtures runs a signing session between t+1 parties of the keygen fixtures, returning the signature sent by
+// each party and the signers' keys
+func signWithFixtures(
+	t *testing.T,
+	newParty func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party,
+) ([]*common.SignatureData, []keygen.LocalPartySaveData) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	return signatures, keys
+}
+
+func TestE2EWithDigest(t *testing.T) {
+	setUp("info")
+
+	// a digest with leading zero bytes, and one longer than the curve order
+	digest := append([]byte{0, 0}, common.SHA512_256([]byte("digest"))[2:]...)
+	longDigest := sha512.Sum512([]byte("long digest"))
+	for _, digest := range [][]byte{digest, longDigest[:]} {
+		signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalPartyWithDigest(digest, params, key, out, end)
+		})
+		pk := keys[0].ECDSAPub.ToECDSAPubKey()
+		for _, data := range signatures {
+			assert.Equal(t, digest, data.M, "the digest should be output as is")
+			ok := ecdsa.Verify(pk, digest, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			der, err := data.DERSignature()
+			if assert.NoError(t, err) {
+				assert.True(t, ecdsa.VerifyASN1(pk, digest, der), "the DER signature must verify")
+			}
+		}
+	}
+
+	msg := []byte("hello, world")
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalPartyWithMessage(msg, crypto.SHA256, params, key, out, end)
+	})
+	sum := sha256.Sum256(msg)
+	assert.Equal(t, sum[:], signatures[0].M)
+	ok := ecdsa.Verify(keys[0].ECDSAPub.ToECDSAPubKey(), sum[:], new(big.Int).SetBytes(signatures[0].R), new(big.Int).SetBytes(signatures[0].S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
+
+func TestE2EWithLowSMode(t *testing.T) {
+	setUp("info")
+
+	digest := common.SHA512_256([]byte("low s"))
+	for _, mode := range []tss.LowSMode{tss.LowSAlways, tss.LowSNever} {
+		signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			params.SetLowS(mode)
+			return NewLocalPartyWithDigest(digest, params, key, out, end)
+		})
+		for _, data := range signatures {
+			s := new(big.Int).SetBytes(data.S)
+			ok := ecdsa.Verify(keys[0].ECDSAPub.ToECDSAPubKey(), digest, new(big.Int).SetBytes(data.R), s)
+			assert.True(t, ok, "ecdsa verify must pass")
+			if mode == tss.LowSAlways {
+				assert.True(t, s.Cmp(new(big.Int).Rsh(tss.S256().Params().N, 1)) <= 0, "s must be low")
+			}
+
+			pk, err := RecoverPublicKey(tss.S256(), digest, data)
+			if assert.NoError(t, err) {
+				assert.True(t, pk.Equals(keys[0].ECDSAPub), "the public key should be recovered")
+			}
+			if rsv, err := data.EthereumSignature(); err == nil {
+				parsed, err := common.ParseEthereumSignature(tss.S256(), rsv)
+				if assert.NoError(t, err) {
+					pk, err := RecoverPublicKey(tss.S256(), digest, parsed)
+					assert.NoError(t, err)
+					assert.True(t, pk.Equals(keys[0].ECDSAPub), "the public key should be recovered from V")
+				}
+			}
+		}
+	}
+}
+
+func TestE2EWithDerivationPath(t *testing.T) {
+	setUp("info")
+
+	// the fixtures predate chain codes, so every signer is given the same one
+	chainCode := common.SHA512_256([]byte("chain code"))
+	path := []uint32{44, 0, 0, 7}
+	version := chaincfg.TestNet3Params.HDPublicKeyID[:]
+	digest := common.SHA512_256([]byte("derived"))
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		key.ChainCode = chainCode
+		P, err := NewLocalPartyWithDerivationPath(digest, path, version, params, key, out, end)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return P
+	})
+
+	key := keys[0]
+	key.ChainCode = chainCode
+	child, err := DeriveChildPublicKey(key, path, version)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint8(len(path)), child.Depth)
+	assert.True(t, strings.HasPrefix(child.String(), "tpub"))
+	parsed, err := ckd.NewExtendedKeyFromString(child.String(), tss.S256())
+	if assert.NoError(t, err) {
+		assert.Equal(t, child.PublicKey, parsed.PublicKey)
+	}
+
+	for _, data := range signatures {
+		assert.Equal(t, elliptic.MarshalCompressed(tss.S256(), child.X, child.Y), data.PublicKey)
+		assert.Equal(t, child.String(), data.ExtendedPublicKey)
+		ok := ecdsa.Verify(&child.PublicKey, digest, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+		assert.True(t, ok, "ecdsa verify must pass with the derived key")
+		ok = ecdsa.Verify(key.ECDSAPub.ToECDSAPubKey(), digest, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+		assert.False(t, ok, "the signature must not verify with the master key")
+	}
+	assert.True(t, key.ECDSAPub.Equals(keys[0].ECDSAPub), "the save data should be left unchanged")
+}
+
+func TestE2EWithTweaks(t *testing.T) {
+	setUp("info")
+
+	N := tss.S256().Params().N
+	digest := common.SHA512_256([]byte("tweaked"))
+	payToContract := tweak.Func(func(pub *crypto2.ECPoint) (*big.Int, *big.Int, error) {
+		return big.NewInt(1), common.SHA512_256i(pub.X(), pub.Y(), new(big.Int).SetBytes([]byte("contract"))), nil
+	})
+	tweaks := []tweak.Tweak{
+		tweak.Additive(common.GetRandomPositiveInt(rand.Reader, N)),
+		tweak.Multiplicative(common.GetRandomPositiveInt(rand.Reader, N)),
+		payToContract,
+	}
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		P, err := NewLocalPartyWithTweaks(digest, tweaks, params, key, out, end)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return P
+	})
+
+	tweaked, err := TweakPublicKey(keys[0], tweaks...)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, data := range signatures {
+		assert.Equal(t, elliptic.MarshalCompressed(tss.S256(), tweaked.X(), tweaked.Y()), data.PublicKey)
+		r, s := new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)
+		assert.True(t, ecdsa.Verify(tweaked.ToECDSAPubKey(), digest, r, s), "ecdsa verify must pass with the tweaked key")
+		assert.False(t, ecdsa.Verify(keys[0].ECDSAPub.ToECDSAPubKey(), digest, r, s), "the signature must not verify with the key")
+	}
+}
+
+func TestDerivationPathErrors(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := keys[0]
+	_, err = DeriveChildPublicKey(key, []uint32{1}, nil)
+	assert.Error(t, err, "the fixture has no chain code")
+
+	key.ChainCode = common.SHA512_256([]byte("chain code"))
+	_, err = DeriveChildPublicKey(key, []uint32{ckd.HardenedKeyStart + 44}, nil)
+	assert.Error(t, err, "hardened derivation is impossible")
+	_, err = DeriveChildPublicKey(key, []uint32{1}, []byte{1, 2})
+	assert.Error(t, err, "the version must be 4 bytes")
+	_, err = NewLocalPartyWithDerivationPath([]byte{1}, []uint32{1}, []byte{1, 2}, nil, key, nil, nil)
+	assert.Error(t, err, "the version must be 4 bytes")
+
+	master, err := DeriveChildPublicKey(key, nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, key.ECDSAPub.X().Cmp(master.X))
+		assert.Equal(t, 0, key.ECDSAPub.Y().Cmp(master.Y))
+	}
+	testnet, err := DeriveChildPublicKey(key, []uint32{1}, chaincfg.TestNet3Params.HDPublicKeyID[:])
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(testnet.String(), "tpub"))
+	}
+}
+
+// The parties sign with new party keys; the shares are located by the share indexes they were created with.
+func TestE2EWithShareIndexes(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys := fixtures[:threshold+1]
+
+	signPIDs := tss.GenerateTestPartyIDs(len(keys))
+	indexes := make([]*big.Int, len(keys))
+	for j, key := range keys {
+		indexes[j] = key.ShareID
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	digest := common.SHA512_256([]byte("share indexes"))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		if !assert.NoError(t, params.SetShareIndexes(indexes)) {
+			return
+		}
+		assert.Error(t, params.SetShareIndexes(indexes[1:]), "there must be one index per signer")
+		P := NewLocalPartyWithDigest(digest, params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	for _, data := range signatures {
+		ok := ecdsa.Verify(pk, digest, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+		assert.True(t, ok, "ecdsa verify must pass")
+	}
+}
+
+func TestE2EWithSignerFallback(t *testing.T) {
+	setUp("info")
+
+	first, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	fixtures, holders, err := keygen.LoadKeygenTestFixtures(len(first[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures) / 2
+	keys := make(map[string]keygen.LocalPartySaveData, len(fixtures))
+	for _, fixture := range fixtures {
+		keys[fixture.ShareID.String()] = fixture
+	}
+	// the first holder would be picked first, but never answers
+	silent := holders[0]
+
+	msg := common.SHA512_256([]byte("fallback"))
+	sessions := 0
+	sigData, excluded, err := tss.SignWithFallback(holders, threshold, func(signers tss.SortedPartyIDs) (*common.SignatureData, *tss.Error) {
+		sessions++
+		p2pCtx := tss.NewPeerContext(signers)
+		parties := make([]tss.Party, len(signers))
+		errCh := make(chan *tss.Error, 2*len(signers))
+		outCh := make(chan tss.Message, 2*len(signers))
+		endCh := make(chan *common.SignatureData, len(signers))
+		done := make(chan struct{})
+		defer close(done)
+
+		live := 0
+		for i, signer := range signers {
+			if signer.KeyInt().Cmp(silent.KeyInt()) == 0 {
+				continue
+			}
+			params := tss.NewParameters(tss.S256(), p2pCtx, signer, len(signers), threshold)
+			P := NewLocalPartyWithDigest(msg, params, keys[signer.KeyInt().String()], outCh, endCh)
+			parties[i] = P
+			live++
+			// the MtA rounds take a while, so a short timeout would also catch the honest signers
+			go func(timeoutCh <-chan *tss.Error) {
+				if err := <-timeoutCh; err != nil {
+					errCh <- err
+				}
+			}(tss.WatchRounds(P, 5*time.Second, done))
+			go func(P tss.Party) {
+				if err := P.Start(); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		var sigData *common.SignatureData
+		for ended := 0; ended < live; {
+			select {
+			case err := <-errCh:
+				return nil, err
+			case msg := <-outCh:
+				for _, P := range parties {
+					if P == nil || P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					if dest := msg.GetTo(); dest == nil || dest[0].Index == P.PartyID().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			case sigData = <-endCh:
+				ended++
+			}
+		}
+		return sigData, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, sessions, "the second session should exclude the silent holder")
+	if assert.Len(t, excluded, 1) {
+		assert.Equal(t, silent, excluded[0])
+	}
+	pk := fixtures[0].ECDSAPub.ToECDSAPubKey()
+	ok := ecdsa.Verify(pk, msg, new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
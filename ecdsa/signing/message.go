@@ -0,0 +1,91 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// NewLocalPartyWithDigest returns a party signing `digest`, the hash of the message computed by the caller.
+// The digest is converted to an integer as crypto/ecdsa does, so the signature verifies with ecdsa.Verify(pub, digest, ...).
+// SignatureData.M holds the digest exactly as given, leading zero bytes included.
+func NewLocalPartyWithDigest(
+	digest []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	if len(digest) == 0 {
+		panic(errors.New("signing.NewLocalPartyWithDigest expected a non-empty digest"))
+	}
+	p := NewLocalParty(digestToInt(digest, params.EC()), params, key, out, end).(*LocalParty)
+	p.temp.digest = append([]byte{}, digest...)
+	return p
+}
+
+// NewLocalPartyWithMessage returns a party signing the digest of `msg` with `hash`, which must be linked into the binary,
+// e.g. by importing crypto/sha256. SignatureData.M holds the digest, see NewLocalPartyWithDigest.
+func NewLocalPartyWithMessage(
+	msg []byte,
+	hash crypto.Hash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalPartyWithDigest(hashMessage(msg, hash), params, key, out, end)
+}
+
+// NewBatchLocalPartyWithDigests is NewBatchLocalParty for digests computed by the caller, see NewLocalPartyWithDigest
+func NewBatchLocalPartyWithDigests(
+	digests [][]byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+) tss.Party {
+	return newBatchLocalParty(len(digests), params, out, end,
+		func(k int, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalPartyWithDigest(digests[k], params, key, out, end)
+		})
+}
+
+// ----- //
+
+func hashMessage(msg []byte, hash crypto.Hash) []byte {
+	if !hash.Available() {
+		panic(fmt.Errorf("signing: the hash function %v is not linked into the binary", hash))
+	}
+	h := hash.New()
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// digestToInt keeps the leftmost bits of the digest up to the bit length of the curve order, as crypto/ecdsa does,
+// and reduces the result modulo the order
+func digestToInt(digest []byte, ec elliptic.Curve) *big.Int {
+	N := ec.Params().N
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	m := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		m.Rsh(m, uint(excess))
+	}
+	return m.Mod(m, N)
+}
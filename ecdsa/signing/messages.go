@@ -8,6 +8,7 @@ package signing
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -34,6 +35,11 @@ var (
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*SignPresignCheckMessage1)(nil),
+		(*SignPresignCheckMessage2)(nil),
+		(*SignIdentifyRound1Message)(nil),
+		(*SignIdentifyRound2Message)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -164,10 +170,14 @@ func (m *SignRound3Message) ValidateBasic() bool {
 
 // ----- //
 
+// NewSignRound4Message de-commits Gamma_i. When signing with an adaptor point Y, it also reveals gamma_i * Y in
+// `adaptorGamma` with `adaptorProof`, a proof that it has the same discrete logarithm as Gamma_i; both are nil otherwise.
 func NewSignRound4Message(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
 	proof *schnorr.ZKProof,
+	adaptorGamma *crypto.ECPoint,
+	adaptorProof *schnorr.ZKDLEQProof,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -180,6 +190,15 @@ func NewSignRound4Message(
 		ProofAlphaY:  proof.Alpha.Y().Bytes(),
 		ProofT:       proof.T.Bytes(),
 	}
+	if adaptorGamma != nil && adaptorProof != nil {
+		content.AdaptorGammaX = adaptorGamma.X().Bytes()
+		content.AdaptorGammaY = adaptorGamma.Y().Bytes()
+		content.AdaptorProofAlphaX = adaptorProof.Alpha.X().Bytes()
+		content.AdaptorProofAlphaY = adaptorProof.Alpha.Y().Bytes()
+		content.AdaptorProofBetaX = adaptorProof.Beta.X().Bytes()
+		content.AdaptorProofBetaY = adaptorProof.Beta.Y().Bytes()
+		content.AdaptorProofT = adaptorProof.T.Bytes()
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
@@ -211,6 +230,36 @@ func (m *SignRound4Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProo
 	}, nil
 }
 
+// UnmarshalAdaptorGamma returns gamma_j * Y and the proof that it has the same discrete logarithm as Gamma_j
+func (m *SignRound4Message) UnmarshalAdaptorGamma(ec elliptic.Curve) (*crypto.ECPoint, *schnorr.ZKDLEQProof, error) {
+	gammaY, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorGammaX()),
+		new(big.Int).SetBytes(m.GetAdaptorGammaY()))
+	if err != nil {
+		return nil, nil, err
+	}
+	alpha, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorProofAlphaX()),
+		new(big.Int).SetBytes(m.GetAdaptorProofAlphaY()))
+	if err != nil {
+		return nil, nil, err
+	}
+	beta, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetAdaptorProofBetaX()),
+		new(big.Int).SetBytes(m.GetAdaptorProofBetaY()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return gammaY, &schnorr.ZKDLEQProof{
+		Alpha: alpha,
+		Beta:  beta,
+		T:     new(big.Int).SetBytes(m.GetAdaptorProofT()),
+	}, nil
+}
+
 // ----- //
 
 func NewSignRound5Message(
@@ -265,8 +314,9 @@ func NewSignRound6Message(
 }
 
 func (m *SignRound6Message) ValidateBasic() bool {
+	// the de-commitment holds V_i and A_i, followed by the commitments of the adaptor DLEQ proof when signing with one
 	return m != nil &&
-		common.NonEmptyMultiBytes(m.DeCommitment, 5) &&
+		(common.NonEmptyMultiBytes(m.DeCommitment, 5) || common.NonEmptyMultiBytes(m.DeCommitment, 9)) &&
 		common.NonEmptyBytes(m.ProofAlphaX) &&
 		common.NonEmptyBytes(m.ProofAlphaY) &&
 		common.NonEmptyBytes(m.ProofT) &&
@@ -366,9 +416,14 @@ func (m *SignRound8Message) UnmarshalDeCommitment() []*big.Int {
 
 // ----- //
 
+// NewSignRound9Message reveals the signature share s_i and the blinding factor l_i of V_i, so that s_i can be checked
+// against V_i. `li` is nil when signing with a presignature. `adaptorT` is the share of the response of the proof that
+// R and R_Y have the same discrete logarithm, nil unless signing with an adaptor point.
 func NewSignRound9Message(
 	from *tss.PartyID,
 	si *big.Int,
+	li *big.Int,
+	adaptorT *big.Int,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -377,6 +432,12 @@ func NewSignRound9Message(
 	content := &SignRound9Message{
 		S: si.Bytes(),
 	}
+	if li != nil {
+		content.L = li.Bytes()
+	}
+	if adaptorT != nil {
+		content.AdaptorProofT = adaptorT.Bytes()
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
@@ -389,3 +450,275 @@ func (m *SignRound9Message) ValidateBasic() bool {
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+func (m *SignRound9Message) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.GetL())
+}
+
+func (m *SignRound9Message) UnmarshalAdaptorT() *big.Int {
+	return new(big.Int).SetBytes(m.GetAdaptorProofT())
+}
+
+// ----- //
+
+func NewSignPresignCheckMessage1(
+	to, from *tss.PartyID,
+	proof *mta.ProofPDLwSlack,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &SignPresignCheckMessage1{
+		ProofPdlWSlack: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignPresignCheckMessage1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetProofPdlWSlack(), mta.ProofPDLwSlackBytesParts)
+}
+
+func (m *SignPresignCheckMessage1) UnmarshalProofPDLwSlack(ec elliptic.Curve) (*mta.ProofPDLwSlack, error) {
+	return mta.ProofPDLwSlackFromBytes(ec, m.GetProofPdlWSlack())
+}
+
+// ----- //
+
+// NewSignPresignCheckMessage2 broadcasts k_i * R, S_i = sigma_i * R and T_i = sigma_i * G + l_i * R, with a proof that
+// S_i and T_i have the same sigma_i
+func NewSignPresignCheckMessage2(
+	from *tss.PartyID,
+	barR, S, T *crypto.ECPoint,
+	proof *schnorr.ZKSTProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignPresignCheckMessage2{
+		BarRX:       barR.X().Bytes(),
+		BarRY:       barR.Y().Bytes(),
+		SX:          S.X().Bytes(),
+		SY:          S.Y().Bytes(),
+		TX:          T.X().Bytes(),
+		TY:          T.Y().Bytes(),
+		ProofAlphaX: proof.Alpha.X().Bytes(),
+		ProofAlphaY: proof.Alpha.Y().Bytes(),
+		ProofBetaX:  proof.Beta.X().Bytes(),
+		ProofBetaY:  proof.Beta.Y().Bytes(),
+		ProofT:      proof.T.Bytes(),
+		ProofU:      proof.U.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignPresignCheckMessage2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBarRX()) &&
+		common.NonEmptyBytes(m.GetBarRY()) &&
+		common.NonEmptyBytes(m.GetSX()) &&
+		common.NonEmptyBytes(m.GetSY()) &&
+		common.NonEmptyBytes(m.GetTX()) &&
+		common.NonEmptyBytes(m.GetTY()) &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofBetaX()) &&
+		common.NonEmptyBytes(m.GetProofBetaY()) &&
+		common.NonEmptyBytes(m.GetProofT()) &&
+		common.NonEmptyBytes(m.GetProofU())
+}
+
+// UnmarshalPoints returns k_j * R, S_j and T_j
+func (m *SignPresignCheckMessage2) UnmarshalPoints(ec elliptic.Curve) (barR, S, T *crypto.ECPoint, err error) {
+	if barR, err = crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetBarRX()), new(big.Int).SetBytes(m.GetBarRY())); err != nil {
+		return nil, nil, nil, err
+	}
+	if S, err = crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetSX()), new(big.Int).SetBytes(m.GetSY())); err != nil {
+		return nil, nil, nil, err
+	}
+	if T, err = crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetTX()), new(big.Int).SetBytes(m.GetTY())); err != nil {
+		return nil, nil, nil, err
+	}
+	return barR, S, T, nil
+}
+
+func (m *SignPresignCheckMessage2) UnmarshalZKSTProof(ec elliptic.Curve) (*schnorr.ZKSTProof, error) {
+	alpha, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetProofAlphaX()), new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	beta, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetProofBetaX()), new(big.Int).SetBytes(m.GetProofBetaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKSTProof{
+		Alpha: alpha,
+		Beta:  beta,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+		U:     new(big.Int).SetBytes(m.GetProofU()),
+	}, nil
+}
+
+// ----- //
+
+// NewSignIdentifyRound1Message reveals the values of the sender needed to identify who cheated. The slices are indexed
+// by party and have no entry for the sender. rho is zero when presigning, which does not sample it.
+func NewSignIdentifyRound1Message(
+	from *tss.PartyID,
+	k *big.Int,
+	kRandomness []*big.Int,
+	gamma, rho, l *big.Int,
+	betas []*big.Int,
+	nus []*crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignIdentifyRound1Message{
+		K:           k.Bytes(),
+		KRandomness: bigIntsToBytesSparse(kRandomness),
+		Gamma:       gamma.Bytes(),
+		Rho:         rho.Bytes(),
+		L:           l.Bytes(),
+		Beta:        bigIntsToBytesSparse(betas),
+		NuX:         make([][]byte, len(nus)),
+		NuY:         make([][]byte, len(nus)),
+	}
+	for j, nu := range nus {
+		if nu != nil {
+			content.NuX[j], content.NuY[j] = nu.X().Bytes(), nu.Y().Bytes()
+		}
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignIdentifyRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetL()) &&
+		common.NonEmptyBytes(m.GetGamma()) &&
+		len(m.GetKRandomness()) == len(m.GetBeta()) &&
+		len(m.GetBeta()) == len(m.GetNuX()) &&
+		len(m.GetNuX()) == len(m.GetNuY())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalKRandomness() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetKRandomness())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalGamma() *big.Int {
+	return new(big.Int).SetBytes(m.GetGamma())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalRho() *big.Int {
+	return new(big.Int).SetBytes(m.GetRho())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.GetL())
+}
+
+func (m *SignIdentifyRound1Message) UnmarshalBetas() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBeta())
+}
+
+// UnmarshalNus returns the points g^nu revealed for each party, with a nil entry for the sender
+func (m *SignIdentifyRound1Message) UnmarshalNus(ec elliptic.Curve, sender int) ([]*crypto.ECPoint, error) {
+	nus := make([]*crypto.ECPoint, len(m.GetNuX()))
+	for j := range nus {
+		if j == sender {
+			continue
+		}
+		nu, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetNuX()[j]), new(big.Int).SetBytes(m.GetNuY()[j]))
+		if err != nil {
+			return nil, err
+		}
+		nus[j] = nu
+	}
+	return nus, nil
+}
+
+// ----- //
+
+func NewSignIdentifyRound2Message(
+	from *tss.PartyID,
+	accused []*tss.PartyID,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	indexes := make([]uint32, len(accused))
+	for j, Pj := range accused {
+		indexes[j] = uint32(Pj.Index)
+	}
+	content := &SignIdentifyRound2Message{
+		Accused: indexes,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignIdentifyRound2Message) ValidateBasic() bool {
+	return m != nil
+}
+
+// UnmarshalAccused returns the indexes of the accused parties, failing if one is out of range
+func (m *SignIdentifyRound2Message) UnmarshalAccused(partyCount int) ([]int, error) {
+	accused := make([]int, len(m.GetAccused()))
+	for j, idx := range m.GetAccused() {
+		if int(idx) >= partyCount {
+			return nil, errors.New("accused party index out of range")
+		}
+		accused[j] = int(idx)
+	}
+	return accused, nil
+}
+
+// ----- //
+
+// NewSignBatchMessage bundles the wire bytes of the messages of the same round of each session of a batch,
+// which have the same routing
+func NewSignBatchMessage(
+	routing tss.MessageRouting,
+	messages [][]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        routing.From,
+		To:          routing.To,
+		IsBroadcast: routing.IsBroadcast,
+	}
+	content := &SignBatchMessage{
+		Messages: messages,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetMessages())
+}
+
+// bigIntsToBytesSparse is like common.BigIntsToBytes, but leaves an empty entry for nil values
+func bigIntsToBytesSparse(bigInts []*big.Int) [][]byte {
+	bzs := make([][]byte, len(bigInts))
+	for j, n := range bigInts {
+		if n != nil {
+			bzs[j] = n.Bytes()
+		}
+	}
+	return bzs
+}
@@ -0,0 +1,359 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// PreSignatureData is this party's output of the presign protocol: rounds 1-4 of signing, which do not depend on
+	// the message, and a round checking them. It is consumed by the online phase, see NewLocalPartyWithPreSignature.
+	//
+	// A presignature must be used for one signature only. Signing two messages with the same presignature reveals the
+	// private key, and it must be kept as secret as the key share.
+	PreSignatureData struct {
+		// identifies the presign session; the same for every signer
+		SSID []byte
+		// the share indexes of the signers, in the order of their party IDs
+		Ks []*big.Int
+		// this party's shares of the nonce k and of sigma = k*x
+		KI, SigmaI *big.Int
+		// R = k^-1 * G, and the public key that the signature will verify against
+		R, ECDSAPub *crypto.ECPoint
+		// each signer's k_j * R and sigma_j * R, in the order of Ks, to check its signature share against
+		BarRs, Ss []*crypto.ECPoint
+	}
+
+	// presignCheck follows round 4 of a presigning party: R is computed, and each party proves that its shares of
+	// k * R = G and of sigma * R = y are consistent with the values of the previous rounds (GG20 section 4.3)
+	presignCheck struct {
+		*round4
+	}
+
+	// presignFinalization checks the proofs and the sums of the presignCheck shares and outputs the presignature.
+	// No message is signed.
+	presignFinalization struct {
+		*presignCheck
+	}
+
+	// onlineRound is the only round of signing with a presignature. It broadcasts the signature share s_i in a
+	// round 9 message and reuses the finalization of the full protocol.
+	onlineRound struct {
+		*round9
+	}
+)
+
+var (
+	_ tss.Round = (*presignCheck)(nil)
+	_ tss.Round = (*presignFinalization)(nil)
+	_ tss.Round = (*onlineRound)(nil)
+)
+
+// NewPresignLocalParty returns a party that runs the message-independent rounds of signing ahead of time.
+// The presignature is sent through `end` once completed. The same signers, in the same order, must later sign with it.
+func NewPresignLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	p := NewLocalPartyWithKDD(nil, params, key, nil, out, nil).(*LocalParty)
+	p.temp.presignEnd = end
+	partyCount := len(params.Parties().IDs())
+	p.temp.presignBarRs = make([]*crypto.ECPoint, partyCount)
+	p.temp.presignSs = make([]*crypto.ECPoint, partyCount)
+	p.temp.presignTs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+// NewLocalPartyWithPreSignature returns a party that signs `msg` in a single round using a presignature from
+// NewPresignLocalParty. The signature is sent through `end` once completed.
+func NewLocalPartyWithPreSignature(
+	msg *big.Int,
+	params *tss.Parameters,
+	preSignature *PreSignatureData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty:    new(tss.BaseParty),
+		params:       params,
+		keys:         keygen.LocalPartySaveData{Ks: preSignature.Ks, ECDSAPub: preSignature.ECDSAPub},
+		temp:         localTempData{},
+		data:         &common.SignatureData{},
+		preSignature: preSignature,
+		out:          out,
+		end:          end,
+	}
+	// msgs init
+	p.temp.localMessageStore = newLocalMessageStore(partyCount)
+	// temp data init
+	p.temp.m = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	p.temp.k = preSignature.KI
+	p.temp.sigma = preSignature.SigmaI
+	p.temp.bigR = preSignature.R
+	p.temp.presignBarRs = preSignature.BarRs
+	p.temp.presignSs = preSignature.Ss
+	// the presignature must not be used twice; the shares are kept by the party only
+	preSignature.KI = nil
+	preSignature.SigmaI = nil
+	return p
+}
+
+// ----- //
+
+func (round *presignCheck) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, err := round.computeR()
+	if err != nil {
+		return err
+	}
+	round.temp.bigR = R
+
+	ec := round.Params().EC()
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// T_i commits to sigma_i with R as the second base, whose discrete logarithm k^-1 is known to no party
+	li := common.GetRandomPositiveInt(round.Rand(), ec.Params().N)
+	barRi, Si := R.ScalarMult(round.temp.k), R.ScalarMult(round.temp.sigma)
+	Ti, err2 := crypto.ScalarBaseMult(ec, round.temp.sigma).Add(R.ScalarMult(li))
+	if err2 != nil {
+		return round.WrapError(errors2.Wrapf(err2, "T_i"))
+	}
+	round.temp.li = li
+	round.temp.presignBarRs[i], round.temp.presignSs[i], round.temp.presignTs[i] = barRi, Si, Ti
+
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	piST, err2 := schnorr.NewZKSTProof(ContextI, Si, Ti, R, round.temp.sigma, li, round.Rand())
+	if err2 != nil {
+		return round.WrapError(errors2.Wrapf(err2, "NewZKSTProof(sigma, l)"))
+	}
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		// k_i * R has the plaintext of the encryption of k_i sent to P_j in round 1 as its discrete logarithm
+		piPDL, err := mta.ProvePDLwSlack(ContextI, ec, round.key.PaillierPKs[i], round.temp.cis[j],
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], R, barRi, round.temp.k, round.temp.cRandomness[j], round.Rand())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "ProvePDLwSlack(k)"))
+		}
+		r5msg1 := NewSignPresignCheckMessage1(Pj, round.PartyID(), piPDL)
+		round.out <- r5msg1
+	}
+	r5msg2 := NewSignPresignCheckMessage2(round.PartyID(), barRi, Si, Ti, piST)
+	round.temp.signPresignCheckMessage2s[i] = r5msg2
+	round.out <- r5msg2
+	return nil
+}
+
+func (round *presignCheck) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.signPresignCheckMessage1s {
+		if round.ok[j] {
+			continue
+		}
+		msg2 := round.temp.signPresignCheckMessage2s[j]
+		if msg1 == nil || !round.CanAccept(msg1) || msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *presignCheck) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignPresignCheckMessage1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*SignPresignCheckMessage2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *presignCheck) NextRound() tss.Round {
+	round.started = false
+	return &presignFinalization{round}
+}
+
+// ----- //
+
+func (round *presignFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	i := round.PartyID().Index
+	R := round.temp.bigR
+
+	// a party with an invalid proof is named without revealing anything
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r1msg1 := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
+		r5msg1 := round.temp.signPresignCheckMessage1s[j].Content().(*SignPresignCheckMessage1)
+		r5msg2 := round.temp.signPresignCheckMessage2s[j].Content().(*SignPresignCheckMessage2)
+		barRj, Sj, Tj, err := r5msg2.UnmarshalPoints(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		piST, err := r5msg2.UnmarshalZKSTProof(ec)
+		if err != nil || !piST.Verify(ContextJ, Sj, Tj, R) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		piPDL, err := r5msg1.UnmarshalProofPDLwSlack(ec)
+		if err != nil || !piPDL.Verify(ContextJ, ec, round.key.PaillierPKs[j], r1msg1.UnmarshalC(),
+			round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i], R, barRj) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.presignBarRs[j], round.temp.presignSs[j], round.temp.presignTs[j] = barRj, Sj, Tj
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("presignature check proof verification failed"), culprits...)
+	}
+
+	// sum(k_j * R) = G and sum(sigma_j * R) = y, or the parties reveal their nonce shares to identify who cheated
+	sumBarR, sumS := round.temp.presignBarRs[0], round.temp.presignSs[0]
+	for j := 1; j < len(round.Parties().IDs()); j++ {
+		var err error
+		if sumBarR, err = sumBarR.Add(round.temp.presignBarRs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumBarR.Add(barRj)"))
+		}
+		if sumS, err = sumS.Add(round.temp.presignSs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "sumS.Add(Sj)"))
+		}
+	}
+	g := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	if !sumBarR.Equals(g) || !sumS.Equals(round.key.ECDSAPub) {
+		common.Logger.Warningf("party %s: the presignature check failed, identifying the cheaters", round.PartyID())
+		round.temp.identifyStage = identifyAfterPresign
+		for j := range round.ok {
+			round.ok[j] = true
+		}
+		return nil
+	}
+
+	preSignature := &PreSignatureData{
+		SSID:     round.temp.ssid,
+		Ks:       round.key.Ks,
+		KI:       round.temp.k,
+		SigmaI:   round.temp.sigma,
+		R:        R,
+		ECDSAPub: round.key.ECDSAPub,
+		BarRs:    round.temp.presignBarRs,
+		Ss:       round.temp.presignSs,
+	}
+
+	// clear temp.w and temp.k from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+
+	round.temp.presignEnd <- preSignature
+	return nil
+}
+
+func (round *presignFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *presignFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *presignFinalization) NextRound() tss.Round {
+	round.started = false
+	if round.temp.identifyStage != identifyNone {
+		r9 := &round9{&round8{&round7{&round6{&round5{round.round4}}}}}
+		return &identification1{&finalization{r9}}
+	}
+	return nil // finished!
+}
+
+// ----- //
+
+func newOnlineRound(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	r1 := newRound1(params, key, data, temp, out, end).(*round1)
+	return &onlineRound{&round9{&round8{&round7{&round6{&round5{&round4{&round3{&round2{r1}}}}}}}}}
+}
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 9
+	round.started = true
+	round.resetOK()
+
+	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid"))
+	}
+	ks := round.ShareIndexes()
+	if len(ks) != len(round.key.Ks) {
+		return round.WrapError(fmt.Errorf("the presignature was made by %d signers, not %d", len(round.key.Ks), len(ks)))
+	}
+	for j, kj := range round.key.Ks {
+		if kj.Cmp(ks[j]) != 0 {
+			return round.WrapError(errors.New("the presignature was made by other signers or in another order"))
+		}
+	}
+	if round.temp.k == nil || round.temp.sigma == nil || round.temp.bigR == nil || round.key.ECDSAPub == nil ||
+		len(round.temp.presignBarRs) != len(ks) || len(round.temp.presignSs) != len(ks) {
+		return round.WrapError(errors.New("the presignature is incomplete"))
+	}
+
+	N := round.Params().EC().Params().N
+	modN := common.ModInt(N)
+	round.temp.rx, round.temp.ry = new(big.Int).Mod(round.temp.bigR.X(), N), round.temp.bigR.Y()
+	round.temp.si = modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(round.temp.rx, round.temp.sigma))
+
+	// clear temp.k and temp.sigma from memory, lint ignore
+	round.temp.k = zero
+	round.temp.sigma = zero
+
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si, nil, nil)
+	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
+	round.out <- r9msg
+	return nil
+}
@@ -0,0 +1,352 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	preSignatureFileExt = ".presig"
+	consumedFileExt     = ".consumed"
+)
+
+type (
+	// PreSignatureStore keeps presignatures until they are used and makes sure that each one is used at most once.
+	// Implementations must remember the IDs of consumed presignatures for as long as the key is in use.
+	PreSignatureStore interface {
+		// Put records a new presignature. It fails with ErrPreSignatureExists if the ID was ever recorded before,
+		// even if that presignature has since been consumed.
+		Put(preSignature *PreSignatureData) error
+		// Take atomically marks the presignature consumed and returns it. It fails with ErrPreSignatureConsumed if it
+		// was taken before and with ErrPreSignatureNotFound if it was never recorded.
+		Take(id string) (*PreSignatureData, error)
+		// Available returns the IDs of the presignatures that were not consumed yet and that were made for the given key
+		// by the signers with the given share indexes, in that order.
+		Available(ecdsaPub *crypto.ECPoint, ks []*big.Int) ([]string, error)
+	}
+
+	// MemoryPreSignatureStore is a PreSignatureStore that does not outlive the process
+	MemoryPreSignatureStore struct {
+		mtx     sync.Mutex
+		entries map[string]*PreSignatureData // nil once consumed
+	}
+
+	// FilePreSignatureStore is a PreSignatureStore keeping each presignature in its own file in a directory.
+	// A presignature is consumed by atomically renaming its file, after which only a record without the secret shares
+	// is kept, so that reuse is refused across process restarts. The directory must not be shared with other stores.
+	FilePreSignatureStore struct {
+		mtx sync.Mutex
+		dir string
+	}
+
+	// preSignatureRecord is what remains of a consumed presignature in a FilePreSignatureStore
+	preSignatureRecord struct {
+		ID       string
+		Ks       []*big.Int
+		ECDSAPub *crypto.ECPoint
+	}
+)
+
+var (
+	_ PreSignatureStore = (*MemoryPreSignatureStore)(nil)
+	_ PreSignatureStore = (*FilePreSignatureStore)(nil)
+
+	// ErrPreSignatureExists is returned when a presignature with the same ID was already recorded
+	ErrPreSignatureExists = errors.New("the presignature was already recorded")
+	// ErrPreSignatureConsumed is returned when a presignature was already used to sign
+	ErrPreSignatureConsumed = errors.New("the presignature was already consumed")
+	// ErrPreSignatureNotFound is returned when no presignature with the ID was recorded
+	ErrPreSignatureNotFound = errors.New("the presignature was not found")
+	// ErrPreSignatureNotAvailable is returned when a presignature is not available to sign with a key and signers,
+	// because it is missing, consumed, or was made for another key or other signers
+	ErrPreSignatureNotAvailable = errors.New("the presignature is not available for this key and these signers")
+)
+
+// ID identifies the presignature. It is the same for every signer of a presign session, so that the signers can agree
+// on which presignature to use for a message.
+func (preSignature *PreSignatureData) ID() string {
+	if preSignature == nil || preSignature.R == nil {
+		return ""
+	}
+	return hex.EncodeToString(common.SHA512_256(preSignature.SSID, preSignature.R.X().Bytes(), preSignature.R.Y().Bytes()))
+}
+
+func (preSignature *PreSignatureData) madeFor(ecdsaPub *crypto.ECPoint, ks []*big.Int) bool {
+	return sameSigners(preSignature.ECDSAPub, preSignature.Ks, ecdsaPub, ks)
+}
+
+// NewLocalPartyFromPreSignatureStore takes the presignature `id` from the store and returns a party that signs `msg`
+// with it using `key`, see NewLocalPartyWithPreSignature. The presignature must be available and made for the public
+// key of `key` by the signers of `params`, in that order, or ErrPreSignatureNotAvailable is returned and the
+// presignature is left in the store. Otherwise it is consumed before the party is returned, so it cannot be used again
+// even if signing later fails.
+func NewLocalPartyFromPreSignatureStore(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	store PreSignatureStore,
+	id string,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) (tss.Party, error) {
+	ks := params.ShareIndexes()
+	ids, err := store.Available(key.ECDSAPub, ks)
+	if err != nil {
+		return nil, err
+	}
+	if !containsID(ids, id) {
+		return nil, ErrPreSignatureNotAvailable
+	}
+	preSignature, err := store.Take(id)
+	if err != nil {
+		return nil, err
+	}
+	if !preSignature.madeFor(key.ECDSAPub, ks) {
+		return nil, ErrPreSignatureNotAvailable
+	}
+	return NewLocalPartyWithPreSignature(msg, params, preSignature, out, end, fullBytesLen...), nil
+}
+
+// ----- //
+
+// NewMemoryPreSignatureStore returns an empty in-memory store
+func NewMemoryPreSignatureStore() *MemoryPreSignatureStore {
+	return &MemoryPreSignatureStore{entries: make(map[string]*PreSignatureData)}
+}
+
+func (store *MemoryPreSignatureStore) Put(preSignature *PreSignatureData) error {
+	id, err := checkPreSignature(preSignature)
+	if err != nil {
+		return err
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, ok := store.entries[id]; ok {
+		return ErrPreSignatureExists
+	}
+	store.entries[id] = preSignature
+	return nil
+}
+
+func (store *MemoryPreSignatureStore) Take(id string) (*PreSignatureData, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	preSignature, ok := store.entries[id]
+	switch {
+	case !ok:
+		return nil, ErrPreSignatureNotFound
+	case preSignature == nil:
+		return nil, ErrPreSignatureConsumed
+	}
+	store.entries[id] = nil
+	return preSignature, nil
+}
+
+func (store *MemoryPreSignatureStore) Available(ecdsaPub *crypto.ECPoint, ks []*big.Int) ([]string, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	ids := make([]string, 0, len(store.entries))
+	for id, preSignature := range store.entries {
+		if preSignature != nil && preSignature.madeFor(ecdsaPub, ks) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// ----- //
+
+// NewFilePreSignatureStore returns a store keeping its presignatures in `dir`, which is created if it does not exist
+func NewFilePreSignatureStore(dir string) (*FilePreSignatureStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FilePreSignatureStore{dir: dir}, nil
+}
+
+func (store *FilePreSignatureStore) Put(preSignature *PreSignatureData) error {
+	id, err := checkPreSignature(preSignature)
+	if err != nil {
+		return err
+	}
+	bz, err := json.Marshal(preSignature)
+	if err != nil {
+		return err
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	if _, err := os.Stat(store.path(id, consumedFileExt)); err == nil {
+		return ErrPreSignatureExists
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	tmp, err := store.writeTemp(bz)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	// a hard link is created atomically and never replaces an existing file
+	if err := os.Link(tmp, store.path(id, preSignatureFileExt)); err != nil {
+		if os.IsExist(err) {
+			return ErrPreSignatureExists
+		}
+		return err
+	}
+	return syncDir(store.dir)
+}
+
+func (store *FilePreSignatureStore) Take(id string) (*PreSignatureData, error) {
+	if _, err := hex.DecodeString(id); err != nil || id == "" {
+		return nil, ErrPreSignatureNotFound
+	}
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	consumedPath := store.path(id, consumedFileExt)
+	// the rename is the point at which the presignature is consumed; it is atomic and made durable before returning
+	if err := os.Rename(store.path(id, preSignatureFileExt), consumedPath); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if _, err := os.Stat(consumedPath); err == nil {
+			return nil, ErrPreSignatureConsumed
+		}
+		return nil, ErrPreSignatureNotFound
+	}
+	if err := syncDir(store.dir); err != nil {
+		return nil, err
+	}
+	bz, err := os.ReadFile(consumedPath)
+	if err != nil {
+		return nil, err
+	}
+	preSignature := new(PreSignatureData)
+	if err := json.Unmarshal(bz, preSignature); err != nil {
+		return nil, err
+	}
+	if preSignature.ID() != id {
+		return nil, fmt.Errorf("the presignature file %s is corrupted", id)
+	}
+	// drop the secret shares from disk, keeping the record of the consumed presignature
+	if bz, err = json.Marshal(&preSignatureRecord{ID: id, Ks: preSignature.Ks, ECDSAPub: preSignature.ECDSAPub}); err != nil {
+		return nil, err
+	}
+	tmp, err := store.writeTemp(bz)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, consumedPath); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	return preSignature, syncDir(store.dir)
+}
+
+func (store *FilePreSignatureStore) Available(ecdsaPub *crypto.ECPoint, ks []*big.Int) ([]string, error) {
+	store.mtx.Lock()
+	defer store.mtx.Unlock()
+	entries, err := os.ReadDir(store.dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), preSignatureFileExt) {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), preSignatureFileExt)
+		bz, err := os.ReadFile(filepath.Join(store.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		record := new(preSignatureRecord)
+		if err := json.Unmarshal(bz, record); err != nil {
+			return nil, err
+		}
+		if sameSigners(record.ECDSAPub, record.Ks, ecdsaPub, ks) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (store *FilePreSignatureStore) path(id, ext string) string {
+	return filepath.Join(store.dir, id+ext)
+}
+
+func (store *FilePreSignatureStore) writeTemp(bz []byte) (string, error) {
+	f, err := os.CreateTemp(store.dir, "tmp-")
+	if err != nil {
+		return "", err
+	}
+	if _, err = f.Write(bz); err == nil {
+		err = f.Sync()
+	}
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// ----- //
+
+func checkPreSignature(preSignature *PreSignatureData) (string, error) {
+	if preSignature == nil || preSignature.KI == nil || preSignature.SigmaI == nil || preSignature.R == nil ||
+		preSignature.ECDSAPub == nil || len(preSignature.Ks) == 0 ||
+		len(preSignature.BarRs) != len(preSignature.Ks) || len(preSignature.Ss) != len(preSignature.Ks) {
+		return "", errors.New("the presignature is incomplete")
+	}
+	return preSignature.ID(), nil
+}
+
+func sameSigners(ecdsaPub *crypto.ECPoint, ks []*big.Int, otherPub *crypto.ECPoint, otherKs []*big.Int) bool {
+	if ecdsaPub == nil || otherPub == nil || !ecdsaPub.Equals(otherPub) || len(ks) != len(otherKs) {
+		return false
+	}
+	for j, kj := range ks {
+		if kj == nil || otherKs[j] == nil || kj.Cmp(otherKs[j]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func containsID(ids []string, id string) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
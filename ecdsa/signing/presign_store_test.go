@@ -0,0 +1,129 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func newTestPreSignature(ecdsaPub *crypto.ECPoint, ks []*big.Int) *PreSignatureData {
+	N := tss.S256().Params().N
+	barRs, ss := make([]*crypto.ECPoint, len(ks)), make([]*crypto.ECPoint, len(ks))
+	for j := range ks {
+		barRs[j] = crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(rand.Reader, N))
+		ss[j] = crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(rand.Reader, N))
+	}
+	return &PreSignatureData{
+		SSID:     []byte("ssid"),
+		Ks:       ks,
+		KI:       common.GetRandomPositiveInt(rand.Reader, N),
+		SigmaI:   common.GetRandomPositiveInt(rand.Reader, N),
+		R:        crypto.ScalarBaseMult(tss.S256(), common.GetRandomPositiveInt(rand.Reader, N)),
+		ECDSAPub: ecdsaPub,
+		BarRs:    barRs,
+		Ss:       ss,
+	}
+}
+
+func testPreSignatureStore(t *testing.T, store PreSignatureStore, reopen func() PreSignatureStore) {
+	ecdsaPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	ks := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	preSignature := newTestPreSignature(ecdsaPub, ks)
+	other := newTestPreSignature(ecdsaPub, []*big.Int{big.NewInt(2), big.NewInt(1)})
+	id := preSignature.ID()
+
+	assert.NoError(t, store.Put(preSignature))
+	assert.NoError(t, store.Put(other))
+	assert.ErrorIs(t, store.Put(preSignature), ErrPreSignatureExists)
+
+	ids, err := store.Available(ecdsaPub, ks)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{id}, ids)
+
+	taken, err := store.Take(id)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 0, taken.KI.Cmp(preSignature.KI))
+	assert.Equal(t, 0, taken.SigmaI.Cmp(preSignature.SigmaI))
+	assert.True(t, taken.R.Equals(preSignature.R))
+	assert.Equal(t, id, taken.ID())
+
+	_, err = store.Take(id)
+	assert.ErrorIs(t, err, ErrPreSignatureConsumed)
+	assert.ErrorIs(t, store.Put(preSignature), ErrPreSignatureExists, "a consumed presignature must not be recorded again")
+	_, err = store.Take("00")
+	assert.ErrorIs(t, err, ErrPreSignatureNotFound)
+
+	ids, err = store.Available(ecdsaPub, ks)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+
+	if reopen == nil {
+		return
+	}
+	store = reopen()
+	_, err = store.Take(id)
+	assert.ErrorIs(t, err, ErrPreSignatureConsumed, "reuse must be refused after a restart")
+	assert.ErrorIs(t, store.Put(preSignature), ErrPreSignatureExists)
+	_, err = store.Take(other.ID())
+	assert.NoError(t, err)
+}
+
+func TestMemoryPreSignatureStore(t *testing.T) {
+	testPreSignatureStore(t, NewMemoryPreSignatureStore(), nil)
+}
+
+func TestFilePreSignatureStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFilePreSignatureStore(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	testPreSignatureStore(t, store, func() PreSignatureStore {
+		store, err := NewFilePreSignatureStore(dir)
+		assert.NoError(t, err)
+		return store
+	})
+}
+
+func TestNewLocalPartyFromPreSignatureStore(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+	ecdsaPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	otherPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(8))
+	preSignature := newTestPreSignature(ecdsaPub, params.ShareIndexes())
+	id := preSignature.ID()
+	store := NewMemoryPreSignatureStore()
+	assert.NoError(t, store.Put(preSignature))
+
+	// another key must neither sign with the presignature nor consume it
+	_, err := NewLocalPartyFromPreSignatureStore(big.NewInt(42), params, keygen.LocalPartySaveData{ECDSAPub: otherPub}, store, id, nil, nil)
+	assert.ErrorIs(t, err, ErrPreSignatureNotAvailable)
+	// nor other signers
+	otherParams := tss.NewParameters(tss.S256(), tss.NewPeerContext(tss.GenerateTestPartyIDs(2, 1)), pIDs[0], len(pIDs), 1)
+	_, err = NewLocalPartyFromPreSignatureStore(big.NewInt(42), otherParams, keygen.LocalPartySaveData{ECDSAPub: ecdsaPub}, store, id, nil, nil)
+	assert.ErrorIs(t, err, ErrPreSignatureNotAvailable)
+	ids, err := store.Available(ecdsaPub, params.ShareIndexes())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{id}, ids, "the presignature should still be available")
+
+	party, err := NewLocalPartyFromPreSignatureStore(big.NewInt(42), params, keygen.LocalPartySaveData{ECDSAPub: ecdsaPub}, store, id, nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, party)
+	_, err = NewLocalPartyFromPreSignatureStore(big.NewInt(42), params, keygen.LocalPartySaveData{ECDSAPub: ecdsaPub}, store, id, nil, nil)
+	assert.ErrorIs(t, err, ErrPreSignatureNotAvailable, "the presignature should be consumed")
+}
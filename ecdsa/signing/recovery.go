@@ -0,0 +1,107 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// RecoverPublicKey returns the public key that the signature `sig` of `digest` verifies against, using the recovery ID
+// in sig.SignatureRecovery. It works on any short Weierstrass curve y^2 = x^3 + ax + b of prime order.
+func RecoverPublicKey(ec elliptic.Curve, digest []byte, sig *common.SignatureData) (*crypto.ECPoint, error) {
+	if sig == nil || len(sig.SignatureRecovery) != 1 || sig.SignatureRecovery[0] > 3 {
+		return nil, errors.New("RecoverPublicKey: the signature has no valid recovery ID")
+	}
+	recid := sig.SignatureRecovery[0]
+	N, P := ec.Params().N, ec.Params().P
+	r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+	if r.Sign() == 0 || r.Cmp(N) >= 0 || s.Sign() == 0 || s.Cmp(N) >= 0 {
+		return nil, errors.New("RecoverPublicKey: r or s is out of range")
+	}
+
+	// R.x is r, or r + N if R.x overflowed the order
+	x := new(big.Int).Set(r)
+	if recid&2 != 0 {
+		x.Add(x, N)
+	}
+	if x.Cmp(P) >= 0 {
+		return nil, errors.New("RecoverPublicKey: R.x is out of range")
+	}
+	y := curveY(ec, x)
+	if y == nil {
+		return nil, errors.New("RecoverPublicKey: r is not the x-coordinate of a point")
+	}
+	if y.Bit(0) != uint(recid&1) {
+		y.Sub(P, y)
+	}
+	R, err := crypto.NewECPoint(ec, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = r^-1 * (s*R - e*G)
+	modN := common.ModInt(N)
+	rInv := modN.ModInverse(r)
+	Q := R.ScalarMult(modN.Mul(s, rInv))
+	if u1 := modN.Mul(modN.Sub(big.NewInt(0), digestToInt(digest, ec)), rInv); u1.Sign() != 0 {
+		if Q, err = Q.Add(crypto.ScalarBaseMult(ec, u1)); err != nil {
+			return nil, err
+		}
+	}
+	return Q, nil
+}
+
+// ----- //
+
+// recoveryID encodes in bit 0 the parity of R.y and in bit 1 whether R.x overflowed the curve order
+func recoveryID(R *crypto.ECPoint) byte {
+	recid := byte(0)
+	if R.X().Cmp(R.Curve().Params().N) >= 0 {
+		recid |= 2
+	}
+	if R.Y().Bit(0) != 0 {
+		recid |= 1
+	}
+	return recid
+}
+
+// normalizeS returns s and the recovery ID, replacing s with N-s when `mode` requires a low S. Negating s is the same as
+// negating R, so the parity bit of the recovery ID is flipped with it.
+func normalizeS(ec elliptic.Curve, s *big.Int, recid byte, mode tss.LowSMode) (*big.Int, byte) {
+	switch mode {
+	case tss.LowSNever:
+		return s, recid
+	case tss.LowSAsRequiredByCurve:
+		if name, ok := tss.GetCurveName(ec); !ok || name != tss.Secp256k1 {
+			return s, recid
+		}
+	}
+	N := ec.Params().N
+	if s.Cmp(new(big.Int).Rsh(N, 1)) > 0 {
+		return new(big.Int).Sub(N, s), recid ^ 1
+	}
+	return s, recid
+}
+
+// curveY returns a y such that (x, y) is on the curve, or nil if there is none. The coefficient a is not part of
+// elliptic.CurveParams, so it is derived from the base point.
+func curveY(ec elliptic.Curve, x *big.Int) *big.Int {
+	params := ec.Params()
+	P := params.P
+	modP := common.ModInt(P)
+	gx3 := modP.Exp(params.Gx, big.NewInt(3))
+	a := modP.Mul(modP.Sub(modP.Sub(modP.Mul(params.Gy, params.Gy), gx3), params.B), modP.ModInverse(params.Gx))
+
+	y2 := modP.Add(modP.Add(modP.Exp(x, big.NewInt(3)), modP.Mul(a, x)), params.B)
+	return new(big.Int).ModSqrt(y2, P)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// signWithRecoveryID signs as finalize does, with a single key
+func signWithRecoveryID(ec elliptic.Curve, d *big.Int, digest []byte, mode tss.LowSMode) *common.SignatureData {
+	N := ec.Params().N
+	modN := common.ModInt(N)
+	for {
+		k := common.GetRandomPositiveInt(rand.Reader, N)
+		R := crypto.ScalarBaseMult(ec, k)
+		r := new(big.Int).Mod(R.X(), N)
+		s := modN.Mul(modN.ModInverse(k), modN.Add(digestToInt(digest, ec), modN.Mul(r, d)))
+		if r.Sign() == 0 || s.Sign() == 0 {
+			continue
+		}
+		s, recid := normalizeS(ec, s, recoveryID(R), mode)
+		return &common.SignatureData{R: r.Bytes(), S: s.Bytes(), SignatureRecovery: []byte{recid}}
+	}
+}
+
+func TestRecoverPublicKey(t *testing.T) {
+	digest := common.SHA512_256([]byte("recover"))
+	curves := []elliptic.Curve{tss.S256(), elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521()}
+	for _, ec := range curves {
+		N := ec.Params().N
+		d := common.GetRandomPositiveInt(rand.Reader, N)
+		pk := crypto.ScalarBaseMult(ec, d)
+		for _, mode := range []tss.LowSMode{tss.LowSAlways, tss.LowSNever, tss.LowSAsRequiredByCurve} {
+			// enough signatures to see both parities of R.y
+			for i := 0; i < 8; i++ {
+				sig := signWithRecoveryID(ec, d, digest, mode)
+				r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+				assert.True(t, ecdsa.Verify(pk.ToECDSAPubKey(), digest, r, s), "ecdsa verify must pass")
+				if mode == tss.LowSAlways {
+					assert.True(t, s.Cmp(new(big.Int).Rsh(N, 1)) <= 0, "s must be low")
+				}
+
+				recovered, err := RecoverPublicKey(ec, digest, sig)
+				if assert.NoError(t, err, ec.Params().Name) {
+					assert.True(t, recovered.Equals(pk), "the public key should be recovered on %s", ec.Params().Name)
+				}
+			}
+		}
+	}
+}
+
+func TestRecoverPublicKeyInvalid(t *testing.T) {
+	ec := tss.S256()
+	digest := common.SHA512_256([]byte("recover"))
+	d := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	sig := signWithRecoveryID(ec, d, digest, tss.LowSAlways)
+
+	invalid := []*common.SignatureData{
+		{R: sig.R, S: sig.S, SignatureRecovery: []byte{4}},
+		{R: sig.R, S: sig.S},
+		{R: sig.R, S: ec.Params().N.Bytes(), SignatureRecovery: sig.SignatureRecovery},
+		{R: nil, S: sig.S, SignatureRecovery: sig.SignatureRecovery},
+	}
+	for _, bad := range invalid {
+		_, err := RecoverPublicKey(ec, digest, bad)
+		assert.Error(t, err)
+	}
+}
+
+func TestNormalizeSByCurve(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), elliptic.P256()} {
+		N := ec.Params().N
+		highS := new(big.Int).Sub(N, big.NewInt(1))
+		s, recid := normalizeS(ec, highS, 1, tss.LowSAsRequiredByCurve)
+		if ec == tss.S256() {
+			assert.Equal(t, 0, s.Cmp(big.NewInt(1)))
+			assert.Equal(t, byte(0), recid)
+		} else {
+			assert.Equal(t, 0, s.Cmp(highS))
+			assert.Equal(t, byte(1), recid)
+		}
+		s, recid = normalizeS(ec, highS, 2, tss.LowSNever)
+		assert.Equal(t, 0, s.Cmp(highS))
+		assert.Equal(t, byte(2), recid)
+	}
+}
@@ -37,7 +37,7 @@ func (round *round1) Start() *tss.Error {
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+	if round.temp.presignEnd == nil && round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
 		return round.WrapError(errors.New("hashed message is not valid"))
 	}
 
@@ -68,12 +68,18 @@ func (round *round1) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		cA, pi, err := mta.AliceInit(round.Params().EC(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
+		// Alice_init, keeping the randomness of cA in case it must be revealed to identify a cheater
+		cA, rA, err := round.key.PaillierPKs[i].EncryptAndReturnRandomness(round.Rand(), k)
+		if err != nil {
+			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+		}
+		pi, err := mta.ProveRangeAlice(round.Params().EC(), round.key.PaillierPKs[i], cA, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rA, round.Rand())
 		if err != nil {
 			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
 		}
 		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
+		round.temp.cRandomness[j] = rA
 		round.out <- r1msg1
 	}
 
@@ -85,20 +91,19 @@ func (round *round1) Start() *tss.Error {
 }
 
 func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
 	for j, msg1 := range round.temp.signRound1Message1s {
 		if round.ok[j] {
 			continue
 		}
-		if msg1 == nil || !round.CanAccept(msg1) {
-			return false, nil
-		}
 		msg2 := round.temp.signRound1Message2s[j]
-		if msg2 == nil || !round.CanAccept(msg2) {
-			return false, nil
+		if msg1 == nil || !round.CanAccept(msg1) || msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
 		}
 		round.ok[j] = true
 	}
-	return true, nil
+	return ret, nil
 }
 
 func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
@@ -111,6 +111,8 @@ func (round *round3) Start() *tss.Error {
 		if j == round.PartyID().Index {
 			continue
 		}
+		round.temp.alphas[j] = new(big.Int).Set(alphas[j])
+		round.temp.us[j] = new(big.Int).Set(us[j])
 		thelta = modN.Add(thelta, alphas[j].Add(alphas[j], round.temp.betas[j]))
 		sigma = modN.Add(sigma, us[j].Add(us[j], round.temp.vs[j]))
 	}
@@ -13,6 +13,7 @@ import (
 	errors2 "github.com/pkg/errors"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -48,7 +49,20 @@ func (round *round4) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)"))
 	}
 	round.temp.thetaInverse = thetaInverse
-	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma)
+
+	// with an adaptor point Y, also reveal gamma_i * Y and prove that it has the same discrete logarithm as Gamma_i
+	var adaptorGamma *crypto.ECPoint
+	var piAdaptorGamma *schnorr.ZKDLEQProof
+	if round.temp.adaptor != nil {
+		adaptorGamma = round.temp.adaptor.ScalarMult(round.temp.gamma)
+		piAdaptorGamma, err = schnorr.NewZKDLEQProof(
+			ContextI, round.temp.gamma, round.temp.pointGamma, round.temp.adaptor, adaptorGamma, round.Rand())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewZKDLEQProof(gamma, bigGamma, adaptorGamma)"))
+		}
+		round.temp.adaptorGammas[i] = adaptorGamma
+	}
+	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma, adaptorGamma, piAdaptorGamma)
 	round.temp.signRound4Messages[round.PartyID().Index] = r4msg
 	round.out <- r4msg
 
@@ -79,5 +93,8 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) NextRound() tss.Round {
 	round.started = false
+	if round.temp.presignEnd != nil {
+		return &presignCheck{round}
+	}
 	return &round5{round}
 }
@@ -26,48 +26,30 @@ func (round *round5) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
-	R := round.temp.pointGamma
-	for j, Pj := range round.Parties().IDs() {
-		if j == round.PartyID().Index {
-			continue
-		}
-		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
-		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
-		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
-		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
-		ok, bigGammaJ := cmtDeCmt.DeCommit()
-		if !ok || len(bigGammaJ) != 2 {
-			return round.WrapError(errors.New("commitment verify failed"), Pj)
-		}
-		bigGammaJPoint, err := crypto.NewECPoint(round.Params().EC(), bigGammaJ[0], bigGammaJ[1])
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
-		}
-		proof, err := r4msg.UnmarshalZKProof(round.Params().EC())
-		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
-		}
-		ok = proof.Verify(ContextJ, bigGammaJPoint)
-		if !ok {
-			return round.WrapError(errors.New("failed to prove bigGamma"), Pj)
-		}
-		R, err = R.Add(bigGammaJPoint)
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
-		}
+	R, rErr := round.computeR()
+	if rErr != nil {
+		return rErr
 	}
 
-	R = R.ScalarMult(round.temp.thetaInverse)
 	N := round.Params().EC().Params().N
 	modN := common.ModInt(N)
-	rx := R.X()
+	// r is R.x reduced modulo the order; R.x itself may exceed it on some curves
+	rx := new(big.Int).Mod(R.X(), N)
 	ry := R.Y()
+	if round.temp.adaptor != nil {
+		// a pre-signature is locked to Y by taking r from R_Y = R^y, the nonce of the completed signature
+		RY, rErr := round.computeRY()
+		if rErr != nil {
+			return rErr
+		}
+		rx = new(big.Int).Mod(RY.X(), N)
+		ry = RY.Y()
+		round.temp.bigRY = RY
+	}
 	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
 
-	// clear temp.w and temp.k from memory, lint ignore
+	// clear temp.w from memory, lint ignore. temp.k is kept to identify a cheater if the phase 5 checks fail
 	round.temp.w = zero
-	round.temp.k = zero
 
 	li := common.GetRandomPositiveInt(round.Rand(), N)  // li
 	roI := common.GetRandomPositiveInt(round.Rand(), N) // pi
@@ -79,7 +61,16 @@ func (round *round5) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "rToSi.Add(li)"))
 	}
 
-	cmt := commitments.NewHashCommitment(round.Rand(), bigVi.X(), bigVi.Y(), bigAi.X(), bigAi.Y())
+	values := []*big.Int{bigVi.X(), bigVi.Y(), bigAi.X(), bigAi.Y()}
+	if round.temp.adaptor != nil {
+		// the parties commit to their shares of the nonce of the DLEQ proof that R and R_Y have the same discrete logarithm
+		ti := common.GetRandomPositiveInt(round.Rand(), N)
+		adaptorAi := crypto.ScalarBaseMult(round.Params().EC(), ti)
+		adaptorBi := round.temp.adaptor.ScalarMult(ti)
+		values = append(values, adaptorAi.X(), adaptorAi.Y(), adaptorBi.X(), adaptorBi.Y())
+		round.temp.adaptorT, round.temp.adaptorAi, round.temp.adaptorBi = ti, adaptorAi, adaptorBi
+	}
+	cmt := commitments.NewHashCommitment(round.Rand(), values...)
 	r5msg := NewSignRound5Message(round.PartyID(), cmt.C)
 	round.temp.signRound5Messages[round.PartyID().Index] = r5msg
 	round.out <- r5msg
@@ -97,6 +88,70 @@ func (round *round5) Start() *tss.Error {
 	return nil
 }
 
+// computeR de-commits and verifies the Gamma_j of the other parties and computes R = (Gamma_1 + ... + Gamma_n)^(theta^-1)
+func (round *base) computeR() (*crypto.ECPoint, *tss.Error) {
+	R := round.temp.pointGamma
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
+		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
+		ok, bigGammaJ := cmtDeCmt.DeCommit()
+		if !ok || len(bigGammaJ) != 2 {
+			return nil, round.WrapError(errors.New("commitment verify failed"), Pj)
+		}
+		bigGammaJPoint, err := crypto.NewECPoint(round.Params().EC(), bigGammaJ[0], bigGammaJ[1])
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
+		}
+		proof, err := r4msg.UnmarshalZKProof(round.Params().EC())
+		if err != nil {
+			return nil, round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, bigGammaJPoint)
+		if !ok {
+			return nil, round.WrapError(errors.New("failed to prove bigGamma"), Pj)
+		}
+		R, err = R.Add(bigGammaJPoint)
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
+		}
+		round.temp.bigGammas[j] = bigGammaJPoint
+	}
+	round.temp.bigGammas[round.PartyID().Index] = round.temp.pointGamma
+
+	return R.ScalarMult(round.temp.thetaInverse), nil
+}
+
+// computeRY verifies that the gamma_j * Y of the other parties have the same discrete logarithms as their Gamma_j and
+// computes R_Y = (gamma_1 * Y + ... + gamma_n * Y)^(theta^-1)
+func (round *round5) computeRY() (*crypto.ECPoint, *tss.Error) {
+	RY := round.temp.adaptorGammas[round.PartyID().Index]
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		adaptorGammaJ, proof, err := r4msg.UnmarshalAdaptorGamma(round.Params().EC())
+		if err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "UnmarshalAdaptorGamma()"), Pj)
+		}
+		if !proof.Verify(ContextJ, round.temp.bigGammas[j], round.temp.adaptor, adaptorGammaJ) {
+			return nil, round.WrapError(errors.New("failed to prove adaptorGamma"), Pj)
+		}
+		if RY, err = RY.Add(adaptorGammaJ); err != nil {
+			return nil, round.WrapError(errors2.Wrapf(err, "RY.Add(adaptorGammaJ)"), Pj)
+		}
+		round.temp.adaptorGammas[j] = adaptorGammaJ
+	}
+	return RY.ScalarMult(round.temp.thetaInverse), nil
+}
+
 func (round *round5) Update() (bool, *tss.Error) {
 	ret := true
 	for j, msg := range round.temp.signRound5Messages {
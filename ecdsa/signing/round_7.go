@@ -15,6 +15,7 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -28,6 +29,10 @@ func (round *round7) Start() *tss.Error {
 
 	bigVjs := make([]*crypto.ECPoint, len(round.Parties().IDs()))
 	bigAjs := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	valuesLen := 4
+	if round.temp.adaptor != nil {
+		valuesLen = 8
+	}
 	for j, Pj := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
 			continue
@@ -38,9 +43,20 @@ func (round *round7) Start() *tss.Error {
 		cj, dj := r5msg.UnmarshalCommitment(), r6msg.UnmarshalDeCommitment()
 		cmtDeCmt := commitments.HashCommitDecommit{C: cj, D: dj}
 		ok, values := cmtDeCmt.DeCommit()
-		if !ok || len(values) != 4 {
+		if !ok || len(values) != valuesLen {
 			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
 		}
+		if round.temp.adaptor != nil {
+			adaptorAj, err := crypto.NewECPoint(round.Params().EC(), values[4], values[5])
+			if err != nil {
+				return round.WrapError(errors2.Wrapf(err, "NewECPoint(adaptorAj)"), Pj)
+			}
+			adaptorBj, err := crypto.NewECPoint(round.Params().EC(), values[6], values[7])
+			if err != nil {
+				return round.WrapError(errors2.Wrapf(err, "NewECPoint(adaptorBj)"), Pj)
+			}
+			round.temp.adaptorAs[j], round.temp.adaptorBs[j] = adaptorAj, adaptorBj
+		}
 		bigVjX, bigVjY, bigAjX, bigAjY := values[0], values[1], values[2], values[3]
 		bigVj, err := crypto.NewECPoint(round.Params().EC(), bigVjX, bigVjY)
 		if err != nil {
@@ -79,6 +95,14 @@ func (round *round7) Start() *tss.Error {
 		AX, AY = round.Params().EC().Add(AX, AY, bigAjs[j].X(), bigAjs[j].Y())
 	}
 
+	bigVjs[round.PartyID().Index], bigAjs[round.PartyID().Index] = round.temp.bigVi, round.temp.bigAi
+	round.temp.bigVs, round.temp.bigAs = bigVjs, bigAjs
+	round.temp.bigV = crypto.NewECPointNoCurveCheck(round.Params().EC(), VX, VY)
+	round.temp.bigA = crypto.NewECPointNoCurveCheck(round.Params().EC(), AX, AY)
+	if err := round.computeAdaptorChallenge(); err != nil {
+		return err
+	}
+
 	UiX, UiY := round.Params().EC().ScalarMult(VX, VY, round.temp.roi.Bytes())
 	TiX, TiY := round.Params().EC().ScalarMult(AX, AY, round.temp.li.Bytes())
 	round.temp.Ui = crypto.NewECPointNoCurveCheck(round.Params().EC(), UiX, UiY)
@@ -92,6 +116,33 @@ func (round *round7) Start() *tss.Error {
 	return nil
 }
 
+// computeAdaptorChallenge sums the commitments of the parties to the nonce of the DLEQ proof that R and R_Y have the
+// same discrete logarithm and computes its challenge; it does nothing unless signing with an adaptor point
+func (round *round7) computeAdaptorChallenge() *tss.Error {
+	if round.temp.adaptor == nil {
+		return nil
+	}
+	i := round.PartyID().Index
+	round.temp.adaptorAs[i], round.temp.adaptorBs[i] = round.temp.adaptorAi, round.temp.adaptorBi
+	A, B := round.temp.adaptorAi, round.temp.adaptorBi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		var err error
+		if A, err = A.Add(round.temp.adaptorAs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "A.Add(adaptorAj)"), Pj)
+		}
+		if B, err = B.Add(round.temp.adaptorBs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "B.Add(adaptorBj)"), Pj)
+		}
+	}
+	round.temp.adaptorA, round.temp.adaptorB = A, B
+	round.temp.adaptorC = schnorr.DLEQChallenge(
+		adaptorSession, round.temp.bigR, round.temp.adaptor, round.temp.bigRY, A, B)
+	return nil
+}
+
 func (round *round7) Update() (bool, *tss.Error) {
 	ret := true
 	for j, msg := range round.temp.signRound7Messages {
@@ -8,7 +8,10 @@ package signing
 
 import (
 	"errors"
+	"math/big"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -23,6 +26,7 @@ func (round *round9) Start() *tss.Error {
 
 	UX, UY := round.temp.Ui.X(), round.temp.Ui.Y()
 	TX, TY := round.temp.Ti.X(), round.temp.Ti.Y()
+	round.temp.bigUs[round.PartyID().Index], round.temp.bigTs[round.PartyID().Index] = round.temp.Ui, round.temp.Ti
 	for j, Pj := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
 			continue
@@ -33,18 +37,36 @@ func (round *round9) Start() *tss.Error {
 		cj, dj := r7msg.UnmarshalCommitment(), r8msg.UnmarshalDeCommitment()
 		cmt := commitments.HashCommitDecommit{C: cj, D: dj}
 		ok, values := cmt.DeCommit()
-		if !ok && len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+		if !ok || len(values) != 4 {
+			return round.WrapError(errors.New("de-commitment for bigUj and bigTj failed"), Pj)
 		}
 		UjX, UjY, TjX, TjY := values[0], values[1], values[2], values[3]
+		round.temp.bigUs[j] = crypto.NewECPointNoCurveCheck(round.Params().EC(), UjX, UjY)
+		round.temp.bigTs[j] = crypto.NewECPointNoCurveCheck(round.Params().EC(), TjX, TjY)
 		UX, UY = round.Params().EC().Add(UX, UY, UjX, UjY)
 		TX, TY = round.Params().EC().Add(TX, TY, TjX, TjY)
 	}
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
-		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
+		// s_i is not revealed; the parties reveal their nonce shares instead to identify who cheated
+		common.Logger.Warningf("party %s: U doesn't equal T, identifying the cheaters", round.PartyID())
+		round.temp.identifyStage = identifyAfterPhase5
+		for j := range round.ok {
+			round.ok[j] = true
+		}
+		return nil
 	}
 
-	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
+	// clear temp.k from memory, lint ignore. it must never be revealed once s_i is public
+	round.temp.k = zero
+
+	// with an adaptor point, the share of the response of the DLEQ proof: z_i = t_i + c * theta^-1 * gamma_i
+	var zi *big.Int
+	if round.temp.adaptor != nil {
+		modN := common.ModInt(round.Params().EC().Params().N)
+		zi = modN.Add(round.temp.adaptorT, modN.Mul(round.temp.adaptorC, modN.Mul(round.temp.thetaInverse, round.temp.gamma)))
+	}
+
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si, round.temp.li, zi)
 	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
 	round.out <- r9msg
 	return nil
@@ -74,5 +96,8 @@ func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round9) NextRound() tss.Round {
 	round.started = false
+	if round.temp.identifyStage != identifyNone {
+		return &identification1{&finalization{round}}
+	}
 	return &finalization{round}
 }
@@ -62,6 +62,15 @@ type (
 	finalization struct {
 		*round9
 	}
+	identification1 struct {
+		*finalization
+	}
+	identification2 struct {
+		*identification1
+	}
+	identificationEnd struct {
+		*identification2
+	}
 )
 
 var (
@@ -75,6 +84,9 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*identification1)(nil)
+	_ tss.Round = (*identification2)(nil)
+	_ tss.Round = (*identificationEnd)(nil)
 )
 
 // ----- //
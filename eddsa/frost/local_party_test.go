@@ -0,0 +1,321 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2E(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, threshold := loadSigners(t)
+	nonces := preprocess(t, keys, signPIDs, threshold)
+	for _, n := range nonces {
+		assert.NotEmpty(t, n.ID())
+		assert.Equal(t, nonces[0].ID(), n.ID(), "every signer should identify the nonces the same way")
+	}
+
+	msg := []byte("frost ed25519")
+	signatures, errs := sign(t, msg, keys, signPIDs, threshold, nonces)
+	if !assert.Empty(t, errs) {
+		return
+	}
+	pk := signatures[0].PublicKey
+	assert.Len(t, pk, ed25519.PublicKeySize)
+	for _, data := range signatures {
+		assert.Equal(t, signatures[0].Signature, data.Signature, "every party should output the same signature")
+		assert.Equal(t, msg, data.M)
+		assert.True(t, ed25519.Verify(pk, msg, data.Signature), "the signature should verify with RFC 8032")
+		sig, err := data.Ed25519Signature()
+		if assert.NoError(t, err) {
+			assert.Equal(t, data.Signature, sig)
+		}
+	}
+
+	// the nonces are cleared once used
+	for _, n := range nonces {
+		assert.Nil(t, n.DI)
+		assert.Nil(t, n.EI)
+	}
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	P := NewLocalParty(msg, params, keys[0], nonces[0], make(chan tss.Message, 1), make(chan *common.SignatureData, 1))
+	if err := P.Start(); assert.Error(t, err, "nonces must not be used twice") {
+		assert.Contains(t, err.Error(), "already used")
+	}
+}
+
+func TestNoncesOfOtherSigners(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, threshold := loadSigners(t)
+	nonces := preprocess(t, keys, signPIDs, threshold)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	swapped := *nonces[0]
+	swapped.Ks = []*big.Int{nonces[0].Ks[1], nonces[0].Ks[0]}
+	swapped.Ks = append(swapped.Ks, nonces[0].Ks[2:]...)
+	P := NewLocalParty([]byte("msg"), params, keys[0], &swapped, make(chan tss.Message, 1), make(chan *common.SignatureData, 1))
+	assert.Error(t, P.Start(), "the nonces were made in another order")
+
+	P = NewLocalParty([]byte("msg"), params, keys[0], nil, make(chan tss.Message, 1), make(chan *common.SignatureData, 1))
+	assert.Error(t, P.Start(), "the nonces are required")
+}
+
+func TestE2EPartialSignatureCulprit(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, threshold := loadSigners(t)
+	nonces := preprocess(t, keys, signPIDs, threshold)
+
+	// the cheater's hiding nonce no longer matches its commitment D_i, so its z_i is invalid
+	cheater := 1
+	nonces[cheater].DI = new(big.Int).Add(nonces[cheater].DI, big.NewInt(1))
+
+	_, errs := sign(t, []byte("partial"), keys, signPIDs, threshold, nonces)
+	if !assert.Len(t, errs, len(signPIDs)) {
+		return
+	}
+	for _, err := range errs {
+		assert.Equal(t, 3, err.Round(), err.Error())
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+		}
+	}
+}
+
+// ----- //
+
+func loadSigners(t *testing.T) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs, int) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	return keys, signPIDs, threshold
+}
+
+func preprocess(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, threshold int) []*NonceData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *NonceData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		parties = append(parties, NewPreprocessLocalParty(params, keys[i], outCh, endCh))
+	}
+	startParties(parties, errCh)
+
+	nonces := make([]*NonceData, len(signPIDs))
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+
+		case n := <-endCh:
+			// the commitment to its own hiding nonce tells which party the output is from
+			Di := crypto.ScalarBaseMult(tss.Edwards(), n.DI)
+			for i, Dj := range n.BigDj {
+				if Dj.Equals(Di) {
+					nonces[i] = n
+				}
+			}
+			ended++
+		}
+	}
+	return nonces
+}
+
+func sign(t *testing.T, msg []byte, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, threshold int, nonces []*NonceData) ([]*common.SignatureData, []*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		parties = append(parties, NewLocalParty(msg, params, keys[i], nonces[i], outCh, endCh))
+	}
+	startParties(parties, errCh)
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	for len(signatures)+len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			routeMessage(parties, msg, errCh)
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	errList := make([]*tss.Error, 0, len(errs))
+	for _, err := range errs {
+		errList = append(errList, err)
+	}
+	return signatures, errList
+}
+
+func startParties(parties []tss.Party, errCh chan<- *tss.Error) {
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+}
+
+func routeMessage(parties []tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	for _, P := range parties {
+		if P.PartyID().Index == msg.GetFrom().Index {
+			continue
+		}
+		go test.SharedPartyUpdater(P, msg, errCh)
+	}
+}
+
+// TestRFC9591Vectors checks the nonces, binding factors, signature shares and signature of the FROST(Ed25519, SHA-512)
+// test vectors of RFC 9591 appendix E.1, signed by participants 1 and 3. The challenge is checked through the shares.
+func TestRFC9591Vectors(t *testing.T) {
+	ec := tss.Edwards()
+	scalar := func(s string) *big.Int {
+		bz, err := hex.DecodeString(s)
+		if err != nil {
+			panic(err)
+		}
+		reverseBytes(bz)
+		return new(big.Int).SetBytes(bz)
+	}
+	hexOf := func(bz []byte) string { return hex.EncodeToString(bz) }
+
+	groupPub := crypto.ScalarBaseMult(ec, scalar("7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304"))
+	assert.Equal(t, "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673", hexOf(elementBytes(groupPub)))
+	msg := []byte("test")
+
+	ks := []*big.Int{big.NewInt(1), big.NewInt(3)}
+	xs := []*big.Int{
+		scalar("929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509"),
+		scalar("d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02"),
+	}
+	vectors := []struct {
+		hidingRandomness, bindingRandomness string
+		hidingNonce, bindingNonce           string
+		hidingCommitment, bindingCommitment string
+		bindingFactor, sigShare             string
+	}{
+		{
+			hidingRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+			bindingRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+			hidingNonce:       "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+			bindingNonce:      "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+			hidingCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+			bindingCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+			bindingFactor:     "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+			sigShare:          "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		},
+		{
+			hidingRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+			bindingRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+			hidingNonce:       "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+			bindingNonce:      "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+			hidingCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+			bindingCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+			bindingFactor:     "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+			sigShare:          "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+		},
+	}
+
+	// round one: the nonces and their commitments
+	ds, es := make([]*big.Int, len(ks)), make([]*big.Int, len(ks))
+	Ds, Es := make([]*crypto.ECPoint, len(ks)), make([]*crypto.ECPoint, len(ks))
+	for j, v := range vectors {
+		hidingRandomness, _ := hex.DecodeString(v.hidingRandomness)
+		bindingRandomness, _ := hex.DecodeString(v.bindingRandomness)
+		var err error
+		ds[j], err = nonceGenerate(bytes.NewReader(hidingRandomness), xs[j])
+		assert.NoError(t, err)
+		es[j], err = nonceGenerate(bytes.NewReader(bindingRandomness), xs[j])
+		assert.NoError(t, err)
+		assert.Equal(t, v.hidingNonce, hexOf(scalarBytes(ds[j])), "hiding nonce of participant %s", ks[j])
+		assert.Equal(t, v.bindingNonce, hexOf(scalarBytes(es[j])), "binding nonce of participant %s", ks[j])
+		Ds[j], Es[j] = crypto.ScalarBaseMult(ec, ds[j]), crypto.ScalarBaseMult(ec, es[j])
+		assert.Equal(t, v.hidingCommitment, hexOf(elementBytes(Ds[j])), "hiding commitment of participant %s", ks[j])
+		assert.Equal(t, v.bindingCommitment, hexOf(elementBytes(Es[j])), "binding commitment of participant %s", ks[j])
+	}
+
+	// round two: the binding factors, the group commitment and the signature shares
+	rhos := bindingFactors(groupPub, msg, ks, Ds, Es)
+	var groupCommitment *crypto.ECPoint
+	for j := range ks {
+		assert.Equal(t, vectors[j].bindingFactor, hexOf(scalarBytes(rhos[j])), "binding factor of participant %s", ks[j])
+		Rj, err := Ds[j].Add(Es[j].ScalarMult(rhos[j]))
+		if !assert.NoError(t, err) {
+			return
+		}
+		if groupCommitment == nil {
+			groupCommitment = Rj
+		} else if groupCommitment, err = groupCommitment.Add(Rj); !assert.NoError(t, err) {
+			return
+		}
+	}
+	c := challenge(groupCommitment, groupPub, msg)
+	modN := common.ModInt(ec.Params().N)
+	z := big.NewInt(0)
+	for j := range ks {
+		wj := PrepareForSigning(ec, j, len(ks), xs[j], ks)
+		zj := modN.Add(modN.Add(ds[j], modN.Mul(es[j], rhos[j])), modN.Mul(wj, c))
+		assert.Equal(t, vectors[j].sigShare, hexOf(scalarBytes(zj)), "signature share of participant %s", ks[j])
+		z = modN.Add(z, zj)
+	}
+
+	sig := append(elementBytes(groupCommitment), scalarBytes(z)...)
+	assert.Equal(t, "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe"+
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b", hexOf(sig))
+	assert.True(t, ed25519.Verify(elementBytes(groupPub), msg, sig), "ed25519 verify must pass")
+}
@@ -0,0 +1,128 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// NonceData is this party's output of the FROST preprocessing round, see RFC 9591 section 5.1. It is consumed by
+	// the online round, see NewLocalParty.
+	//
+	// The nonces must be used for one signature only. Signing two messages with the same nonces reveals the key share,
+	// and they must be kept as secret as the key share.
+	NonceData struct {
+		// the share indexes of the signers, in the order of their party IDs
+		Ks []*big.Int
+		// this party's hiding and binding nonces; nil once used
+		DI, EI *big.Int
+		// the hiding and binding nonce commitments D_j and E_j of every signer, in the order of Ks
+		BigDj, BigEj []*crypto.ECPoint
+		// the public key that the signature will verify against
+		EDDSAPub *crypto.ECPoint
+	}
+
+	// preprocessFinalization follows round 1 of a preprocessing party: the commitments are collected, but no message
+	// is signed
+	preprocessFinalization struct {
+		*round1
+	}
+)
+
+var (
+	_ tss.Round = (*preprocessFinalization)(nil)
+)
+
+// NewPreprocessLocalParty returns a party that runs the preprocessing round of FROST ahead of time: every signer
+// commits to a pair of nonces. The nonces are sent through `end` once every commitment is received. The same signers,
+// in the same order, must later sign with them.
+func NewPreprocessLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *NonceData,
+) tss.Party {
+	p := newLocalParty(params, key, out, nil)
+	p.temp.preprocessEnd = end
+	return p
+}
+
+// ID identifies the nonces. It is the same for every signer of a preprocessing session, so that the signers can agree
+// on which nonces to use for a message.
+func (nonces *NonceData) ID() string {
+	if nonces == nil || len(nonces.BigDj) == 0 || len(nonces.BigDj) != len(nonces.BigEj) {
+		return ""
+	}
+	in := make([][]byte, 0, 2*len(nonces.BigDj))
+	for j := range nonces.BigDj {
+		in = append(in, elementBytes(nonces.BigDj[j]), elementBytes(nonces.BigEj[j]))
+	}
+	return hex.EncodeToString(common.SHA512_256(in...))
+}
+
+// ----- //
+
+func (round *preprocessFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	partyCount := len(round.Parties().IDs())
+	nonces := &NonceData{
+		Ks:       round.key.Ks,
+		DI:       round.temp.nonces.DI,
+		EI:       round.temp.nonces.EI,
+		BigDj:    make([]*crypto.ECPoint, partyCount),
+		BigEj:    make([]*crypto.ECPoint, partyCount),
+		EDDSAPub: round.key.EDDSAPub,
+	}
+	for j, msg := range round.temp.signRound1Messages {
+		round.ok[j] = true
+		Dj, Ej, err := msg.Content().(*SignRound1Message).UnmarshalCommitments(ec)
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "UnmarshalCommitments(Dj, Ej)"), round.Parties().IDs()[j])
+		}
+		if !isPrimeOrderElement(Dj) || !isPrimeOrderElement(Ej) {
+			return round.WrapError(errors.New("a nonce commitment is not in the prime order subgroup"), round.Parties().IDs()[j])
+		}
+		nonces.BigDj[j], nonces.BigEj[j] = Dj, Ej
+	}
+
+	// clear the nonces from the temp data, lint ignore
+	round.temp.nonces = nil
+
+	round.temp.preprocessEnd <- nonces
+	return nil
+}
+
+func (round *preprocessFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preprocessFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preprocessFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
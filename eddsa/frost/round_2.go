@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// newOnlineRound returns round 2, the only round of signing with the nonces of a preprocessing session
+func newOnlineRound(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	r1 := newRound1(params, key, data, temp, out, end).(*round1)
+	r1.number = 2
+	return &round2{r1}
+}
+
+// round 2 computes the group commitment R and the signature share z_i, see RFC 9591 section 5.2
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+	i := round.PartyID().Index
+	nonces := round.temp.nonces
+
+	// 1. compute the binding factors and R = sum(D_j + rho_j * E_j)
+	round.temp.rhos = bindingFactors(round.key.EDDSAPub, round.temp.m, round.key.Ks, nonces.BigDj, nonces.BigEj)
+	R, err := round.nonceCommitment(0)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "nonceCommitment(0)"), round.Parties().IDs()[0])
+	}
+	for j := 1; j < len(nonces.BigDj); j++ {
+		Rj, err := round.nonceCommitment(j)
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "nonceCommitment(j)"), round.Parties().IDs()[j])
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "the group commitment R is invalid"))
+		}
+	}
+	round.temp.bigR = R
+	round.temp.c = challenge(R, round.key.EDDSAPub, round.temp.m)
+
+	// 2. compute z_i = d_i + rho_i * e_i + lambda_i * c * x_i, in which w_i = lambda_i * x_i
+	zi := modN.Add(nonces.DI, modN.Mul(round.temp.rhos[i], nonces.EI))
+	zi = modN.Add(zi, modN.Mul(round.temp.c, round.temp.wi))
+	round.temp.zi = zi
+
+	// clear the nonces so that they cannot be used again, lint ignore
+	nonces.DI, nonces.EI = nil, nil
+
+	// 3. broadcast z_i to other parties
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// helper to check the nonces and call into PrepareForSigning()
+func (round *round2) prepare() error {
+	i := round.PartyID().Index
+	ks := round.key.Ks
+	nonces := round.temp.nonces
+
+	if nonces == nil {
+		return errors.New("the nonces of a preprocessing session are required")
+	}
+	if nonces.DI == nil || nonces.EI == nil {
+		return errors.New("the nonces were already used")
+	}
+	if len(nonces.Ks) != len(ks) || len(nonces.BigDj) != len(ks) || len(nonces.BigEj) != len(ks) {
+		return fmt.Errorf("the nonces were made by %d signers, not %d", len(nonces.Ks), len(ks))
+	}
+	for j, kj := range nonces.Ks {
+		if kj.Cmp(ks[j]) != 0 {
+			return errors.New("the nonces were made by other signers or in another order")
+		}
+	}
+	if !nonces.EDDSAPub.Equals(round.key.EDDSAPub) {
+		return errors.New("the nonces were made for another key")
+	}
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	round.temp.wi = PrepareForSigning(round.Params().EC(), i, len(ks), round.key.Xi, ks)
+	return nil
+}
+
+// nonceCommitment returns the commitment D_j + rho_j * E_j of party j to its nonce
+func (round *round2) nonceCommitment(j int) (*crypto.ECPoint, error) {
+	return round.temp.nonces.BigDj[j].Add(round.temp.nonces.BigEj[j].ScalarMult(round.temp.rhos[j]))
+}
@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/sha512"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// contextString is the domain separation of the FROST(Ed25519, SHA-512) ciphersuite, see RFC 9591 section 6.1
+const contextString = "FROST-ED25519-SHA512-v1"
+
+const (
+	scalarLen  = 32
+	elementLen = 32
+)
+
+// nonceGenerate is nonce_generate of RFC 9591 section 4.1: H3(random_bytes(32) || SerializeScalar(secret)). Hashing
+// in the key share protects the nonce against a weak source of randomness.
+func nonceGenerate(rand io.Reader, secret *big.Int) (*big.Int, error) {
+	randomBytes, err := common.GetRandomBytes(rand, 32)
+	if err != nil {
+		return nil, err
+	}
+	return hashToScalar([]byte(contextString+"nonce"), randomBytes, scalarBytes(secret)), nil
+}
+
+// bindingFactors returns the binding factor rho_j of each signer, which ties its nonces to the message, to the key and
+// to the nonce commitments of every signer, see RFC 9591 section 4.4. The identifier of a signer is its share index.
+func bindingFactors(pub *crypto.ECPoint, msg []byte, ks []*big.Int, hiding, binding []*crypto.ECPoint) []*big.Int {
+	// the commitment list is sorted by identifier, whatever the order of the signers' party IDs
+	order := make([]int, len(ks))
+	for j := range order {
+		order[j] = j
+	}
+	sort.Slice(order, func(a, b int) bool { return ks[order[a]].Cmp(ks[order[b]]) < 0 })
+	commitmentList := make([]byte, 0, len(ks)*(scalarLen+2*elementLen))
+	for _, j := range order {
+		commitmentList = append(commitmentList, scalarBytes(ks[j])...)
+		commitmentList = append(commitmentList, elementBytes(hiding[j])...)
+		commitmentList = append(commitmentList, elementBytes(binding[j])...)
+	}
+
+	msgHash := sha512.Sum512(append([]byte(contextString+"msg"), msg...))
+	commitmentHash := sha512.Sum512(append([]byte(contextString+"com"), commitmentList...))
+	prefix := append(elementBytes(pub), msgHash[:]...)
+	prefix = append(prefix, commitmentHash[:]...)
+
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		rhos[j] = hashToScalar([]byte(contextString+"rho"), prefix, scalarBytes(kj))
+	}
+	return rhos
+}
+
+// challenge is H2(SerializeElement(R) || SerializeElement(PK) || msg), the challenge of RFC 8032
+func challenge(R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	return hashToScalar(elementBytes(R), elementBytes(pub), msg)
+}
+
+// hashToScalar reduces the SHA-512 of its inputs, read little endian, modulo the group order
+func hashToScalar(in ...[]byte) *big.Int {
+	h := sha512.New()
+	for _, bz := range in {
+		h.Write(bz)
+	}
+	digest := h.Sum(nil)
+	reverseBytes(digest)
+	s := new(big.Int).SetBytes(digest)
+	return s.Mod(s, edwards.Edwards().Params().N)
+}
+
+// scalarBytes is SerializeScalar, the 32-byte little endian encoding of a scalar
+func scalarBytes(k *big.Int) []byte {
+	bz := new(big.Int).Mod(k, edwards.Edwards().Params().N).FillBytes(make([]byte, scalarLen))
+	reverseBytes(bz)
+	return bz
+}
+
+// elementBytes is SerializeElement, the 32-byte encoding of a point of RFC 8032
+func elementBytes(p *crypto.ECPoint) []byte {
+	return edwards.NewPublicKey(p.X(), p.Y()).Serialize()
+}
+
+// isPrimeOrderElement reports whether `p` is in the subgroup of prime order and not the identity, as RFC 9591 requires
+// of the elements received from other signers
+func isPrimeOrderElement(p *crypto.ECPoint) bool {
+	if p.X().Sign() == 0 {
+		return false // the identity (0, 1), or the point (0, -1) of order 2
+	}
+	return p.EightInvEight().Equals(p)
+}
+
+func reverseBytes(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
@@ -44,16 +44,33 @@ type (
 
 	localTempData struct {
 		localMessageStore
+		keyTempData // the first (or only) key
 
 		// temp data (thrown away after keygen)
+		ssid      []byte
+		ssidNonce *big.Int
+
+		// commitments of the parties to their contributions to the chain code, and the de-commitment of ours
+		chainCodeCmts     []cmt.HashCommitment
+		chainCodeDeCommit cmt.HashDeCommitment
+
+		// batch keygen: the additional keys generated in this session
+		batch    []*batchKey
+		batchEnd chan<- []*LocalPartySaveData
+	}
+
+	// temp data of a single key; a batch keygen generates several keys in one session
+	keyTempData struct {
 		ui            *big.Int // used for tests
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+	}
 
-		ssid      []byte
-		ssidNonce *big.Int
+	batchKey struct {
+		keyTempData
+		save LocalPartySaveData
 	}
 )
 
@@ -80,6 +97,31 @@ func NewLocalParty(
 	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.chainCodeCmts = make([]cmt.HashCommitment, partyCount)
+	return p
+}
+
+// NewBatchLocalParty creates a party that generates `batchSize` independent keys in a single keygen session,
+// sending the VSS messages of all of the keys together. Every party must use the same batch size.
+// The save data of the keys is sent through `end` once completed, in the same order for every party.
+func NewBatchLocalParty(
+	params *tss.Parameters,
+	batchSize int,
+	out chan<- tss.Message,
+	end chan<- []*LocalPartySaveData,
+) tss.Party {
+	if batchSize < 1 {
+		panic(errors.New("keygen.NewBatchLocalParty expected a batch size of at least 1"))
+	}
+	p := NewLocalParty(params, out, nil).(*LocalParty)
+	p.temp.batchEnd = end
+	p.temp.batch = make([]*batchKey, batchSize-1)
+	for k := range p.temp.batch {
+		p.temp.batch[k] = &batchKey{
+			keyTempData: keyTempData{KGCs: make([]cmt.HashCommitment, params.PartyCount())},
+			save:        NewLocalPartySaveData(params.PartyCount()),
+		}
+	}
 	return p
 }
 
@@ -236,3 +236,221 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestE2EWithShareIndexes(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	indexes := make([]*big.Int, len(pIDs))
+	for j := range indexes {
+		indexes[j] = big.NewInt(int64(j + 1))
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		if !assert.NoError(t, params.SetShareIndexes(indexes)) {
+			return
+		}
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			saves = append(saves, save)
+			if len(saves) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	shares := make(vss.Shares, 0, len(saves))
+	for _, save := range saves {
+		assert.NoError(t, save.Validate(threshold))
+		assert.Equal(t, indexes, save.Ks)
+		index, err := save.OriginalIndex()
+		assert.NoError(t, err)
+		assert.Equal(t, indexes[index], save.ShareID)
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+	}
+	x, err := shares[:threshold+1].ReConstruct(tss.Edwards())
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), x).Equals(saves[0].EDDSAPub), "the shares at the chosen indexes should reconstruct the key")
+
+	// a subset selected by share index must line up with those indexes
+	subset := BuildLocalSaveDataSubsetWithIndexes(*saves[0], indexes[1:threshold+2])
+	assert.Equal(t, indexes[1:threshold+2], subset.Ks)
+	assert.True(t, subset.BigXj[0].Equals(saves[0].BigXj[1]))
+}
+
+func TestDuplicateShareIndexes(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	indexes := make([]*big.Int, len(pIDs))
+	for j := range indexes {
+		indexes[j] = big.NewInt(1)
+	}
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	err := params.SetShareIndexes(indexes)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "duplicate indexes")
+	}
+	assert.Error(t, params.SetShareIndexes(indexes[:len(pIDs)-1]), "there must be one index per party")
+	assert.Error(t, params.SetShareIndexes(append(indexes[:len(pIDs)-1:len(pIDs)-1], big.NewInt(0))), "an index must not be 0")
+
+	// the rejected indexes are not used, so keygen falls back to the party keys
+	assert.Equal(t, params.Parties().IDs().Keys(), params.ShareIndexes())
+}
+
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+
+	const batchSize = 3
+	threshold := testThreshold
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan []*LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		P := NewBatchLocalParty(params, batchSize, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	batches := make([][]*LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case saves := <-endCh:
+			batches = append(batches, saves)
+			if len(batches) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	for k := 0; k < batchSize; k++ {
+		shares := make(vss.Shares, 0, len(batches))
+		for _, saves := range batches {
+			if !assert.Len(t, saves, batchSize) {
+				return
+			}
+			save := saves[k]
+			assert.NoError(t, save.Validate(threshold))
+			assert.True(t, save.EDDSAPub.Equals(batches[0][k].EDDSAPub), "the parties should agree on each key")
+			assert.Len(t, save.ChainCode, ChainCodeLen)
+			assert.Equal(t, batches[0][k].ChainCode, save.ChainCode, "the parties should agree on each chain code")
+			shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+		}
+		x, err := shares[:threshold+1].ReConstruct(tss.Edwards())
+		assert.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), x).Equals(batches[0][k].EDDSAPub))
+		if k > 0 {
+			assert.False(t, batches[0][k].EDDSAPub.Equals(batches[0][k-1].EDDSAPub), "the keys should be independent")
+			assert.NotEqual(t, batches[0][k].ChainCode, batches[0][k-1].ChainCode, "the chain codes should be independent")
+		}
+	}
+}
+
+func TestBatchSizeMismatch(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	out := make(chan tss.Message, len(pIDs))
+
+	// P[1] generates one key less than the others
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		batchSize := 3
+		if i == 1 {
+			batchSize = 2
+		}
+		P := NewBatchLocalParty(params, batchSize, out, nil).(*LocalParty)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		parties = append(parties, P)
+	}
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	for range pIDs {
+		msg := <-out
+		if msg.GetFrom().Index == 0 {
+			continue
+		}
+		test.SharedPartyUpdater(parties[0], msg, errCh)
+	}
+	close(errCh)
+	err := <-errCh
+	if !assert.NotNil(t, err, "a party with another batch size should be rejected") {
+		return
+	}
+	assert.Equal(t, []*tss.PartyID{pIDs[1]}, err.Culprits())
+}
@@ -32,31 +32,46 @@ var (
 
 // ----- //
 
-func NewKGRound1Message(from *tss.PartyID, ct cmt.HashCommitment) tss.ParsedMessage {
+func NewKGRound1Message(from *tss.PartyID, ct, chainCodeCt cmt.HashCommitment, batchCts ...cmt.HashCommitment) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &KGRound1Message{
-		Commitment: ct.Bytes(),
+		Commitment:          ct.Bytes(),
+		ChainCodeCommitment: chainCodeCt.Bytes(),
+	}
+	for _, batchCt := range batchCts {
+		content.BatchCommitments = append(content.BatchCommitments, batchCt.Bytes())
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound1Message) ValidateBasic() bool {
-	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+	return m != nil && common.NonEmptyBytes(m.GetCommitment()) && common.NonEmptyBytes(m.GetChainCodeCommitment()) &&
+		(len(m.GetBatchCommitments()) == 0 || common.NonEmptyMultiBytes(m.GetBatchCommitments()))
 }
 
 func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 	return new(big.Int).SetBytes(m.GetCommitment())
 }
 
+func (m *KGRound1Message) UnmarshalChainCodeCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetChainCodeCommitment())
+}
+
+// UnmarshalBatchCommitments returns the commitments of the additional keys of a batch keygen
+func (m *KGRound1Message) UnmarshalBatchCommitments() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBatchCommitments())
+}
+
 // ----- //
 
 func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
+	batchShares ...*vss.Share,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -66,25 +81,37 @@ func NewKGRound2Message1(
 	content := &KGRound2Message1{
 		Share: share.Share.Bytes(),
 	}
+	for _, batchShare := range batchShares {
+		content.BatchShares = append(content.BatchShares, batchShare.Share.Bytes())
+	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message1) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.GetShare())
+		common.NonEmptyBytes(m.GetShare()) &&
+		(len(m.GetBatchShares()) == 0 || common.NonEmptyMultiBytes(m.GetBatchShares()))
 }
 
 func (m *KGRound2Message1) UnmarshalShare() *big.Int {
 	return new(big.Int).SetBytes(m.Share)
 }
 
+// UnmarshalBatchShares returns the shares of the additional keys of a batch keygen
+func (m *KGRound2Message1) UnmarshalBatchShares() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBatchShares())
+}
+
 // ----- //
 
 func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
+	chainCodeDeCommitment cmt.HashDeCommitment,
 	proof *schnorr.ZKProof,
+	batchDeCommitments []cmt.HashDeCommitment,
+	batchProofs []*schnorr.ZKProof,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -96,14 +123,31 @@ func NewKGRound2Message2(
 		ProofAlphaX:  proof.Alpha.X().Bytes(),
 		ProofAlphaY:  proof.Alpha.Y().Bytes(),
 		ProofT:       proof.T.Bytes(),
+
+		ChainCodeDeCommitment: common.BigIntsToBytes(chainCodeDeCommitment),
+	}
+	for k, batchDeCommitment := range batchDeCommitments {
+		content.Batch = append(content.Batch, &KGRound2Message2_BatchKey{
+			DeCommitment: common.BigIntsToBytes(batchDeCommitment),
+			ProofAlphaX:  batchProofs[k].Alpha.X().Bytes(),
+			ProofAlphaY:  batchProofs[k].Alpha.Y().Bytes(),
+			ProofT:       batchProofs[k].T.Bytes(),
+		})
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound2Message2) ValidateBasic() bool {
-	return m != nil &&
-		common.NonEmptyMultiBytes(m.GetDeCommitment())
+	if m == nil || !common.NonEmptyMultiBytes(m.GetDeCommitment()) || !common.NonEmptyMultiBytes(m.GetChainCodeDeCommitment()) {
+		return false
+	}
+	for _, key := range m.GetBatch() {
+		if !common.NonEmptyMultiBytes(key.GetDeCommitment()) {
+			return false
+		}
+	}
+	return true
 }
 
 func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
@@ -111,6 +155,10 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+func (m *KGRound2Message2) UnmarshalChainCodeDeCommitment() cmt.HashDeCommitment {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetChainCodeDeCommitment())
+}
+
 func (m *KGRound2Message2) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
 		ec,
@@ -124,3 +172,33 @@ func (m *KGRound2Message2) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof
 		T:     new(big.Int).SetBytes(m.GetProofT()),
 	}, nil
 }
+
+// UnmarshalBatchDeCommitments returns the de-commitments of the additional keys of a batch keygen
+func (m *KGRound2Message2) UnmarshalBatchDeCommitments() []cmt.HashDeCommitment {
+	batch := m.GetBatch()
+	deComs := make([]cmt.HashDeCommitment, len(batch))
+	for k, key := range batch {
+		deComs[k] = cmt.NewHashDeCommitmentFromBytes(key.GetDeCommitment())
+	}
+	return deComs
+}
+
+// UnmarshalBatchZKProofs returns the Schnorr proofs of the additional keys of a batch keygen
+func (m *KGRound2Message2) UnmarshalBatchZKProofs(ec elliptic.Curve) ([]*schnorr.ZKProof, error) {
+	batch := m.GetBatch()
+	proofs := make([]*schnorr.ZKProof, len(batch))
+	for k, key := range batch {
+		point, err := crypto.NewECPoint(
+			ec,
+			new(big.Int).SetBytes(key.GetProofAlphaX()),
+			new(big.Int).SetBytes(key.GetProofAlphaY()))
+		if err != nil {
+			return nil, err
+		}
+		proofs[k] = &schnorr.ZKProof{
+			Alpha: point,
+			T:     new(big.Int).SetBytes(key.GetProofT()),
+		}
+	}
+	return proofs, nil
+}
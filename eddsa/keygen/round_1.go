@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -44,43 +45,62 @@ func (round *round1) Start() *tss.Error {
 	}
 	round.temp.ssid = ssid
 
-	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Params().EC().Params().N)
-	round.temp.ui = ui
-
-	// 2. compute the vss shares
-	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
-	if err != nil {
+	ids := round.ShareIndexes()
+	if len(ids) != round.PartyCount() {
+		return round.WrapError(fmt.Errorf("got %d share indexes for %d parties", len(ids), round.PartyCount()), Pi)
+	}
+	if _, err := vss.CheckIndexes(round.EC(), ids); err != nil {
 		return round.WrapError(err, Pi)
 	}
-	round.save.Ks = ids
 
-	// security: the original u_i may be discarded
-	ui = zero // clears the secret data from memory
-	_ = ui    // silences a linter warning
+	// the VSS steps run once for each key of a batch keygen
+	temps, saves := round.keys()
+	cmtCs := make([]cmts.HashCommitment, len(temps))
+	for k, temp := range temps {
+		// 1. calculate "partial" key share ui
+		ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Params().EC().Params().N)
+		temp.ui = ui
+
+		// 2. compute the vss shares
+		vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		saves[k].Ks = ids
 
-	// 3. make commitment -> (C, D)
-	pGFlat, err := crypto.FlattenECPoints(vs)
-	if err != nil {
-		return round.WrapError(err, Pi)
-	}
-	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+		// security: the original u_i may be discarded
+		ui = zero // clears the secret data from memory
+		_ = ui    // silences a linter warning
 
-	// for this P: SAVE
-	// - shareID
-	// and keep in temporary storage:
-	// - VSS Vs
-	// - our set of Shamir shares
-	round.save.ShareID = ids[i]
-	round.temp.vs = vs
-	round.temp.shares = shares
+		// 3. make commitment -> (C, D)
+		pGFlat, err := crypto.FlattenECPoints(vs)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+		// for this P: SAVE
+		// - shareID
+		// and keep in temporary storage:
+		// - VSS Vs
+		// - our set of Shamir shares
+		saves[k].ShareID = ids[i]
+		temp.vs = vs
+		temp.shares = shares
+
+		temp.deCommitPolyG = cmt.D
+		cmtCs[k] = cmt.C
+	}
 
-	round.temp.deCommitPolyG = cmt.D
+	// commit to a random contribution to the chain code, so that no party can choose its contribution after seeing the
+	// others
+	chainCodeCmt := cmts.NewHashCommitment(round.Rand(), common.MustGetRandomInt(round.Rand(), 8*ChainCodeLen))
+	round.temp.chainCodeCmts[i] = chainCodeCmt.C
+	round.temp.chainCodeDeCommit = chainCodeCmt.D
 
 	// BROADCAST commitments
 	{
-		msg := NewKGRound1Message(round.PartyID(), cmt.C)
+		msg := NewKGRound1Message(round.PartyID(), cmtCs[0], chainCodeCmt.C, cmtCs[1:]...)
 		round.temp.kgRound1Messages[i] = msg
 		round.out <- msg
 	}
@@ -8,11 +8,15 @@ package keygen
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	errors2 "github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -29,13 +33,25 @@ func (round *round2) Start() *tss.Error {
 	// 4. store r1 message pieces
 	for j, msg := range round.temp.kgRound1Messages {
 		r1msg := msg.Content().(*KGRound1Message)
+		if len(r1msg.GetBatchCommitments()) != len(round.temp.batch) {
+			return round.WrapError(fmt.Errorf("expected commitments to %d keys but got %d",
+				1+len(round.temp.batch), 1+len(r1msg.GetBatchCommitments())), msg.GetFrom())
+		}
 		round.temp.KGCs[j] = r1msg.UnmarshalCommitment()
+		round.temp.chainCodeCmts[j] = r1msg.UnmarshalChainCodeCommitment()
+		for k, KGCk := range r1msg.UnmarshalBatchCommitments() {
+			round.temp.batch[k].KGCs[j] = KGCk
+		}
 	}
 
 	// 3. p2p send share ij to Pj
 	shares := round.temp.shares
 	for j, Pj := range round.Parties().IDs() {
-		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j])
+		batchShares := make([]*vss.Share, len(round.temp.batch))
+		for k, key := range round.temp.batch {
+			batchShares[k] = key.shares[j]
+		}
+		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j], batchShares...)
 		// do not send to this Pj, but store for round 3
 		if j == i {
 			round.temp.kgRound2Message1s[j] = r2msg1
@@ -52,8 +68,20 @@ func (round *round2) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ui, vi0)"))
 	}
 
+	batchDeCommitments := make([]cmt.HashDeCommitment, len(round.temp.batch))
+	batchProofs := make([]*schnorr.ZKProof, len(round.temp.batch))
+	for k, key := range round.temp.batch {
+		// the proofs of the additional keys of a batch keygen are also bound to the key number
+		ContextK := common.AppendBigIntToBytesSlice(ContextI, big.NewInt(int64(k+1)))
+		batchProofs[k], err = schnorr.NewZKProof(ContextK, key.ui, key.vs[0], round.Rand())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewZKProof(ui, vi0)"))
+		}
+		batchDeCommitments[k] = key.deCommitPolyG
+	}
+
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, round.temp.chainCodeDeCommit, pii, batchDeCommitments, batchProofs)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
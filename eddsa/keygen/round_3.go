@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/hashicorp/go-multierror"
@@ -15,7 +16,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
-	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -30,29 +31,56 @@ func (round *round3) Start() *tss.Error {
 
 	Ps := round.Parties().IDs()
 	PIdx := round.PartyID().Index
+	temps, saves := round.keys()
+
+	// the shares, de-commitments and proofs of every key must be present
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+			if len(r2msg1.GetBatchShares()) != len(round.temp.batch) || len(r2msg2.GetBatch()) != len(round.temp.batch) {
+				culprits = append(culprits, Pj)
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(fmt.Errorf("expected shares and de-commitments of %d keys", len(temps)), culprits...)
+		}
+	}
 
 	// 1,10. calculate xi
-	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
-	for j := range Ps {
-		if j == PIdx {
-			continue
+	for k, temp := range temps {
+		xi := new(big.Int).Set(temp.shares[PIdx].Share)
+		for j := range Ps {
+			if j == PIdx {
+				continue
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			share := r2msg1.UnmarshalShare()
+			if k > 0 {
+				share = r2msg1.UnmarshalBatchShares()[k-1]
+			}
+			xi = new(big.Int).Add(xi, share)
 		}
-		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
-		share := r2msg1.UnmarshalShare()
-		xi = new(big.Int).Add(xi, share)
+		saves[k].Xi = new(big.Int).Mod(xi, round.Params().EC().Params().N)
 	}
-	round.save.Xi = new(big.Int).Mod(xi, round.Params().EC().Params().N)
 
 	// 2-3.
-	Vc := make(vss.Vs, round.Threshold()+1)
-	for c := range Vc {
-		Vc[c] = round.temp.vs[c] // ours
+	Vcs := make([]vss.Vs, len(temps))
+	for k, temp := range temps {
+		Vcs[k] = make(vss.Vs, round.Threshold()+1)
+		for c := range Vcs[k] {
+			Vcs[k][c] = temp.vs[c] // ours
+		}
 	}
 
 	// 4-12.
 	type vssOut struct {
 		unWrappedErr error
-		pjVs         vss.Vs
+		pjVs         []vss.Vs // one for each key
 	}
 	chs := make([]chan vssOut, len(Ps))
 	for i := range chs {
@@ -69,48 +97,62 @@ func (round *round3) Start() *tss.Error {
 
 		// 6-9.
 		go func(j int, ch chan<- vssOut) {
-			// 4-10.
-			KGCj := round.temp.KGCs[j]
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
 			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
-			KGDj := r2msg2.UnmarshalDeCommitment()
-			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
-			ok, flatPolyGs := cmtDeCmt.DeCommit()
-			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
-				return
-			}
-
-			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
-			for i, PjV := range PjVs {
-				PjVs[i] = PjV.EightInvEight()
-			}
-
-			if err != nil {
-				ch <- vssOut{err, nil}
-				return
-			}
 			proof, err := r2msg2.UnmarshalZKProof(round.Params().EC())
 			if err != nil {
 				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
 				return
 			}
-			ok = proof.Verify(ContextJ, PjVs[0])
-			if !ok {
-				ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
+			batchProofs, err := r2msg2.UnmarshalBatchZKProofs(round.Params().EC())
+			if err != nil {
+				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
 				return
 			}
-			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
-			PjShare := vss.Share{
-				Threshold: round.Threshold(),
-				ID:        round.PartyID().KeyInt(),
-				Share:     r2msg1.UnmarshalShare(),
-			}
-			if ok = PjShare.Verify(round.Params().EC(), round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
-				return
+			KGDjs := append([]cmt.HashDeCommitment{r2msg2.UnmarshalDeCommitment()}, r2msg2.UnmarshalBatchDeCommitments()...)
+			sharesj := append([]*big.Int{r2msg1.UnmarshalShare()}, r2msg1.UnmarshalBatchShares()...)
+			PjVss := make([]vss.Vs, len(temps))
+			for k, temp := range temps {
+				// 4-10.
+				cmtDeCmt := cmt.HashCommitDecommit{C: temp.KGCs[j], D: KGDjs[k]}
+				ok, flatPolyGs := cmtDeCmt.DeCommit()
+				if !ok || flatPolyGs == nil {
+					ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+					return
+				}
+
+				PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
+				for i, PjV := range PjVs {
+					PjVs[i] = PjV.EightInvEight()
+				}
+
+				if err != nil {
+					ch <- vssOut{err, nil}
+					return
+				}
+				ContextK, proofK := ContextJ, proof
+				if k > 0 {
+					ContextK = common.AppendBigIntToBytesSlice(ContextJ, big.NewInt(int64(k)))
+					proofK = batchProofs[k-1]
+				}
+				ok = proofK.Verify(ContextK, PjVs[0])
+				if !ok {
+					ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
+					return
+				}
+				PjShare := vss.Share{
+					Threshold: round.Threshold(),
+					ID:        round.ShareIndex(),
+					Share:     sharesj[k],
+				}
+				if ok = PjShare.Verify(round.Params().EC(), round.Threshold(), PjVs); !ok {
+					ch <- vssOut{errors.New("vss verify failed"), nil}
+					return
+				}
+				PjVss[k] = PjVs
 			}
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{nil, PjVss}
 		}(j, chs[j])
 	}
 
@@ -138,7 +180,7 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
-	{
+	for k, Vc := range Vcs {
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 		for j, Pj := range Ps {
@@ -146,7 +188,7 @@ func (round *round3) Start() *tss.Error {
 				continue
 			}
 			// 11-12.
-			PjVs := vssResults[j].pjVs
+			PjVs := vssResults[j].pjVs[k]
 			for c := 0; c <= round.Threshold(); c++ {
 				Vc[c], err = Vc[c].Add(PjVs[c])
 				if err != nil {
@@ -160,14 +202,14 @@ func (round *round3) Start() *tss.Error {
 	}
 
 	// 13-17. compute Xj for each Pj
-	{
+	modQ := common.ModInt(round.Params().EC().Params().N)
+	for k, Vc := range Vcs {
 		var err error
-		modQ := common.ModInt(round.Params().EC().Params().N)
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
-		bigXj := round.save.BigXj
+		bigXj := saves[k].BigXj
 		for j := 0; j < round.PartyCount(); j++ {
 			Pj := round.Parties().IDs()[j]
-			kj := Pj.KeyInt()
+			kj := saves[k].Ks[j]
 			BigXj := Vc[0]
 			z := new(big.Int).SetInt64(int64(1))
 			for c := 1; c <= round.Threshold(); c++ {
@@ -182,19 +224,48 @@ func (round *round3) Start() *tss.Error {
 		if len(culprits) > 0 {
 			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
 		}
-		round.save.BigXj = bigXj
-	}
+		saves[k].BigXj = bigXj
 
-	// 18. compute and SAVE the EDDSA public key `y`
-	eddsaPubKey, err := crypto.NewECPoint(round.Params().EC(), Vc[0].X(), Vc[0].Y())
-	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+		// 18. compute and SAVE the EDDSA public key `y`
+		eddsaPubKey, err := crypto.NewECPoint(round.Params().EC(), Vc[0].X(), Vc[0].Y())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+		}
+		saves[k].EDDSAPub = eddsaPubKey
+
+		// PRINT public key & private share
+		common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
 	}
-	round.save.EDDSAPub = eddsaPubKey
 
-	// PRINT public key & private share
-	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
+	// compute and SAVE the chain code of each key from the contributions of all of the parties
+	{
+		contributions := make([][]byte, len(Ps))
+		culprits := make([]*tss.PartyID, 0, len(Ps))
+		for j, Pj := range Ps {
+			D := round.temp.chainCodeDeCommit
+			if j != PIdx {
+				D = round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2).UnmarshalChainCodeDeCommitment()
+			}
+			cmtDeCmt := cmt.HashCommitDecommit{C: round.temp.chainCodeCmts[j], D: D}
+			ok, secrets := cmtDeCmt.DeCommit()
+			if !ok || len(secrets) != 1 || secrets[0].BitLen() > 8*ChainCodeLen {
+				culprits = append(culprits, Pj)
+				continue
+			}
+			contributions[j] = secrets[0].FillBytes(make([]byte, ChainCodeLen))
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("chain code de-commitment verify failed"), culprits...)
+		}
+		for k := range saves {
+			saves[k].ChainCode = chainCode(contributions, k)
+		}
+	}
 
+	if round.temp.batchEnd != nil {
+		round.temp.batchEnd <- saves
+		return nil
+	}
 	round.end <- round.save
 	return nil
 }
@@ -212,3 +283,10 @@ func (round *round3) Update() (bool, *tss.Error) {
 func (round *round3) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// chainCode derives the chain code of the k-th key of a keygen session from the contributions of the parties, each
+// of which is enough to make it unpredictable
+func chainCode(contributions [][]byte, k int) []byte {
+	in := append([][]byte{[]byte("tss-lib eddsa chain code"), big.NewInt(int64(k)).Bytes()}, contributions...)
+	return common.SHA512_256(in...)
+}
@@ -86,6 +86,17 @@ func (round *base) resetOK() {
 	}
 }
 
+// keys returns the temp and save data of each key generated in this session, the first (or only) key first
+func (round *base) keys() ([]*keyTempData, []*LocalPartySaveData) {
+	temps := []*keyTempData{&round.temp.keyTempData}
+	saves := []*LocalPartySaveData{round.save}
+	for _, key := range round.temp.batch {
+		temps = append(temps, &key.keyTempData)
+		saves = append(saves, &key.save)
+	}
+	return temps, saves
+}
+
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
 	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
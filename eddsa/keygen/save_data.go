@@ -8,12 +8,18 @@ package keygen
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
+// ChainCodeLen is the byte length of the chain code used to derive child keys
+const ChainCodeLen = 32
+
 type (
 	LocalSecrets struct {
 		// secret fields (not shared, but stored locally)
@@ -32,6 +38,10 @@ type (
 
 		// used for test assertions (may be discarded)
 		EDDSAPub *crypto.ECPoint // y
+
+		// chain code generated jointly with the key, to derive non-hardened child keys with. nil for keys generated
+		// before keygen produced one
+		ChainCode []byte
 	}
 )
 
@@ -41,17 +51,73 @@ func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	return
 }
 
+// Validate checks that the save data is self-consistent for a key shared with the given threshold.
+// The Ks must be distinct and non-zero, Xi*G must match this party's entry in BigXj and the BigXj must interpolate to EDDSAPub.
+func (save LocalPartySaveData) Validate(threshold int) error {
+	if save.EDDSAPub == nil || !save.EDDSAPub.ValidateBasic() {
+		return errors.New("Validate: EDDSAPub is missing or not on the curve")
+	}
+	ec := save.EDDSAPub.Curve()
+	partyCount := len(save.Ks)
+	if len(save.BigXj) != partyCount {
+		return errors.New("Validate: the per-party slices in the save data have different lengths")
+	}
+	if save.ChainCode != nil && len(save.ChainCode) != ChainCodeLen {
+		return fmt.Errorf("Validate: the chain code must be %d bytes", ChainCodeLen)
+	}
+	if threshold < 0 || partyCount <= threshold {
+		return fmt.Errorf("Validate: invalid threshold %d for a key shared by %d parties", threshold, partyCount)
+	}
+	if save.Xi == nil || save.ShareID == nil {
+		return errors.New("Validate: Xi or ShareID is missing")
+	}
+	i := -1
+	for j, kj := range save.Ks {
+		if kj == nil {
+			return fmt.Errorf("Validate: Ks[%d] is missing", j)
+		}
+		if kj.Cmp(save.ShareID) == 0 {
+			i = j
+		}
+	}
+	if i < 0 {
+		return errors.New("Validate: ShareID was not found in Ks")
+	}
+	if _, err := vss.CheckIndexes(ec, save.Ks); err != nil {
+		return fmt.Errorf("Validate: Ks: %v", err)
+	}
+	for j, Xj := range save.BigXj {
+		if Xj == nil || !Xj.SetCurve(ec).ValidateBasic() {
+			return fmt.Errorf("Validate: BigXj[%d] is missing or not on the curve", j)
+		}
+	}
+	if !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		return fmt.Errorf("Validate: Xi*G does not match BigXj[%d]", i)
+	}
+	if err := vss.CheckPublicShares(ec, threshold, save.Ks, save.BigXj, save.EDDSAPub); err != nil {
+		return fmt.Errorf("Validate: %v", err)
+	}
+	return nil
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
+	return BuildLocalSaveDataSubsetWithIndexes(sourceData, sortedIDs.Keys())
+}
+
+// BuildLocalSaveDataSubsetWithIndexes re-creates the LocalPartySaveData to contain data for only the shares with the
+// given indexes, in that order. Use it when the shares were created with explicit indexes, see `tss.Parameters.SetShareIndexes`.
+func BuildLocalSaveDataSubsetWithIndexes(sourceData LocalPartySaveData, indexes []*big.Int) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
 	for j, kj := range sourceData.Ks {
 		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
 	}
-	newData := NewLocalPartySaveData(sortedIDs.Len())
+	newData := NewLocalPartySaveData(len(indexes))
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
-	for j, id := range sortedIDs {
-		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+	newData.ChainCode = sourceData.ChainCode
+	for j, kj := range indexes {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(kj.Bytes())]
 		if !ok {
 			panic("BuildLocalSaveDataSubset: unable to find a signer party in the local save data")
 		}
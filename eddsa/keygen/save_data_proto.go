@@ -0,0 +1,154 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SaveDataVersion is the PersistedSaveData schema version written by MarshalSaveData
+	SaveDataVersion = 1
+)
+
+// ErrUnknownSaveDataVersion is returned when a persisted record was written with a schema this version of tss-lib does not know
+var ErrUnknownSaveDataVersion = errors.New("unknown save data version")
+
+// MarshalSaveData encodes the save data into the versioned PersistedSaveData protobuf format.
+// The curve is taken from EDDSAPub and must be present in the tss curve registry.
+func MarshalSaveData(save *LocalPartySaveData) ([]byte, error) {
+	pb, err := save.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// UnmarshalSaveData decodes save data previously encoded with MarshalSaveData.
+// An error wrapping ErrUnknownSaveDataVersion is returned for records written by a newer schema.
+func UnmarshalSaveData(bz []byte) (*LocalPartySaveData, error) {
+	pb := new(PersistedSaveData)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	return NewSaveDataFromProto(pb)
+}
+
+// ConvertJSONSaveData converts save data persisted as JSON of the LocalPartySaveData struct into the PersistedSaveData format.
+// Points without a curve name in the JSON are assumed to be on the default curve returned by tss.EC().
+func ConvertJSONSaveData(jsonBz []byte) ([]byte, error) {
+	save := new(LocalPartySaveData)
+	if err := json.Unmarshal(jsonBz, save); err != nil {
+		return nil, err
+	}
+	return MarshalSaveData(save)
+}
+
+// ToProto converts the save data into its versioned protobuf representation
+func (save *LocalPartySaveData) ToProto() (*PersistedSaveData, error) {
+	if save.EDDSAPub == nil {
+		return nil, errors.New("ToProto: the save data has no EDDSAPub")
+	}
+	ec := save.EDDSAPub.Curve()
+	ecName, ok := tss.GetCurveName(ec)
+	if !ok {
+		return nil, fmt.Errorf("ToProto: cannot find %T name in curve registry", ec)
+	}
+	if len(save.BigXj) != len(save.Ks) {
+		return nil, errors.New("ToProto: the per-party slices in the save data have different lengths")
+	}
+	pb := &PersistedSaveData{
+		Version:   SaveDataVersion,
+		Curve:     string(ecName),
+		Xi:        bigIntBytes(save.Xi),
+		ShareId:   bigIntBytes(save.ShareID),
+		Ks:        make([][]byte, len(save.Ks)),
+		BigXJ:     make([]*PersistedSaveData_ECPoint, len(save.BigXj)),
+		EddsaPub:  ecPointToProto(save.EDDSAPub),
+		ChainCode: save.ChainCode,
+	}
+	for j, kj := range save.Ks {
+		pb.Ks[j] = bigIntBytes(kj)
+	}
+	for j, Xj := range save.BigXj {
+		pb.BigXJ[j] = ecPointToProto(Xj)
+	}
+	return pb, nil
+}
+
+// NewSaveDataFromProto converts a PersistedSaveData record back into LocalPartySaveData
+func NewSaveDataFromProto(pb *PersistedSaveData) (*LocalPartySaveData, error) {
+	switch v := pb.GetVersion(); {
+	case v == 0:
+		return nil, errors.New("NewSaveDataFromProto: the record has no version")
+	case v > SaveDataVersion:
+		return nil, fmt.Errorf("%w: record version %d, latest supported %d", ErrUnknownSaveDataVersion, v, SaveDataVersion)
+	}
+	ec, ok := tss.GetCurveByName(tss.CurveName(pb.GetCurve()))
+	if !ok {
+		return nil, fmt.Errorf("NewSaveDataFromProto: cannot find curve named %q in curve registry", pb.GetCurve())
+	}
+	partyCount := len(pb.GetKs())
+	if len(pb.GetBigXJ()) != partyCount {
+		return nil, errors.New("NewSaveDataFromProto: the per-party fields in the record have different lengths")
+	}
+	save := NewLocalPartySaveData(partyCount)
+	save.Xi, save.ShareID = bigIntFromBytes(pb.GetXi()), bigIntFromBytes(pb.GetShareId())
+	var err error
+	for j := 0; j < partyCount; j++ {
+		save.Ks[j] = bigIntFromBytes(pb.GetKs()[j])
+		if save.BigXj[j], err = ecPointFromProto(ec, pb.GetBigXJ()[j]); err != nil {
+			return nil, fmt.Errorf("NewSaveDataFromProto: BigXj[%d]: %v", j, err)
+		}
+	}
+	if save.EDDSAPub, err = ecPointFromProto(ec, pb.GetEddsaPub()); err != nil {
+		return nil, fmt.Errorf("NewSaveDataFromProto: EDDSAPub: %v", err)
+	}
+	if len(pb.GetChainCode()) != 0 {
+		save.ChainCode = pb.GetChainCode()
+	}
+	return &save, nil
+}
+
+// ----- //
+
+func ecPointToProto(p *crypto.ECPoint) *PersistedSaveData_ECPoint {
+	if p == nil {
+		return nil
+	}
+	return &PersistedSaveData_ECPoint{X: p.X().Bytes(), Y: p.Y().Bytes()}
+}
+
+func ecPointFromProto(ec elliptic.Curve, pb *PersistedSaveData_ECPoint) (*crypto.ECPoint, error) {
+	if pb == nil {
+		return nil, errors.New("missing point")
+	}
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(pb.GetX()), new(big.Int).SetBytes(pb.GetY()))
+}
+
+func bigIntBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
+
+func bigIntFromBytes(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
@@ -0,0 +1,43 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveDataValidate(t *testing.T) {
+	fixtures, _, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	// the fixtures carry their own party count, which may differ from testParticipants
+	partyCount := len(fixtures[0].Ks)
+	threshold := partyCount / 2
+	for _, fixture := range fixtures {
+		assert.NoError(t, fixture.Validate(threshold))
+	}
+	assert.Error(t, fixtures[0].Validate(threshold-1), "the public shares should not lie on a lower degree polynomial")
+	assert.Error(t, fixtures[0].Validate(partyCount), "the threshold must be below the party count")
+
+	save := fixtures[0]
+	save.Xi = new(big.Int).Add(save.Xi, big.NewInt(1))
+	assert.Error(t, save.Validate(threshold), "Xi should not match BigXj")
+
+	save = fixtures[0]
+	save.BigXj = append(save.BigXj[:0:0], save.BigXj...)
+	save.BigXj[1], save.BigXj[2] = save.BigXj[2], save.BigXj[1]
+	assert.Error(t, save.Validate(threshold), "swapped public shares should not interpolate to the public key")
+
+	save = fixtures[0]
+	save.Ks = append(save.Ks[:0:0], save.Ks...)
+	save.Ks[1] = save.Ks[2]
+	assert.Error(t, save.Validate(threshold), "duplicate Ks should be rejected")
+}
@@ -72,7 +72,7 @@ func NewLocalParty(
 	oldPartyCount := len(params.OldParties().IDs())
 	subset := key
 	if params.IsOldCommittee() {
-		subset = keygen.BuildLocalSaveDataSubset(key, params.OldParties().IDs())
+		subset = keygen.BuildLocalSaveDataSubsetWithIndexes(key, params.ShareIndexes())
 	}
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
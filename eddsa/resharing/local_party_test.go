@@ -66,8 +66,12 @@ func TestE2EConcurrent(t *testing.T) {
 
 	updater := test.SharedPartyUpdater
 
+	// the chain code is carried over to the new committee
+	chainCode := common.SHA512_256([]byte("chain code"))
+
 	// init the old parties first
 	for j, pID := range oldPIDs {
+		oldKeys[j].ChainCode = chainCode
 		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
@@ -145,6 +149,7 @@ func TestE2EConcurrent(t *testing.T) {
 					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+					assert.Equal(t, chainCode, key.ChainCode)
 				}
 
 				// more verification of signing is implemented within local_party_test.go of keygen package
@@ -47,7 +47,7 @@ func (round *round1) Start() *tss.Error {
 	if round.Threshold()+1 > len(ks) {
 		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
 	}
-	newKs := round.NewParties().IDs().Keys()
+	newKs := round.NewShareIndexes()
 	wi := signing.PrepareForSigning(round.Params().EC(), i, len(round.OldParties().IDs()), xi, ks)
 
 	// 2.
@@ -70,7 +70,7 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.EDDSAPub, vCmt.C)
+		round.input.EDDSAPub, vCmt.C, round.input.ChainCode)
 	round.temp.dgRound1Messages[i] = r1msg
 	round.out <- r1msg
 
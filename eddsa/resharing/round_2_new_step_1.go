@@ -7,8 +7,11 @@
 package resharing
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -29,6 +32,21 @@ func (round *round2) Start() *tss.Error {
 	Pi := round.PartyID()
 	i := Pi.Index
 
+	// check that the old committee agrees on the chain code and SAVE it; it is empty for keys without one
+	chainCode := round.temp.dgRound1Messages[0].Content().(*DGRound1Message).GetChainCode()
+	for j, Pj := range round.OldParties().IDs() {
+		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
+		if !bytes.Equal(chainCode, r1msg.GetChainCode()) {
+			return round.WrapError(errors.New("chain code mismatch"), Pj)
+		}
+	}
+	if len(chainCode) != 0 {
+		if len(chainCode) != keygen.ChainCodeLen {
+			return round.WrapError(fmt.Errorf("the chain code must be %d bytes", keygen.ChainCodeLen))
+		}
+		round.save.ChainCode = chainCode
+	}
+
 	// 1. "broadcast" "ACK" members of the OLD committee
 	r2msg := NewDGRound2Message(round.OldParties().IDs(), Pi)
 	round.temp.dgRound2Messages[i] = r2msg
@@ -69,7 +69,7 @@ func (round *round4) Start() *tss.Error {
 		r3msg1 := round.temp.dgRound3Message1s[j].Content().(*DGRound3Message1)
 		sharej := &vss.Share{
 			Threshold: round.NewThreshold(),
-			ID:        round.PartyID().KeyInt(),
+			ID:        round.NewShareIndex(),
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
 		if ok := sharej.Verify(round.Params().EC(), round.NewThreshold(), vj); !ok {
@@ -103,7 +103,7 @@ func (round *round4) Start() *tss.Error {
 	culprits := make([]*tss.PartyID, 0, round.NewPartyCount()) // who caused the error(s)
 	for j := 0; j < round.NewPartyCount(); j++ {
 		Pj := round.NewParties().IDs()[j]
-		kj := Pj.KeyInt()
+		kj := round.NewShareIndexes()[j]
 		newBigXj := Vc[0]
 		newKs = append(newKs, kj)
 		z := new(big.Int).SetInt64(int64(1))
@@ -25,7 +25,7 @@ func (round *round5) Start() *tss.Error {
 	if round.IsNewCommittee() {
 		// for this P: SAVE data
 		round.save.BigXj = round.temp.newBigXjs
-		round.save.ShareID = round.PartyID().KeyInt()
+		round.save.ShareID = round.NewShareIndex()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
 
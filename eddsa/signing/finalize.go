@@ -12,6 +12,8 @@ import (
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 )
@@ -24,6 +26,11 @@ func (round *finalization) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
+	// check each s_j before aggregating, so that an invalid one is attributed to its sender
+	if culprits := round.verifyPartialSignatures(); len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature verification failed"), culprits...)
+	}
+
 	sumS := round.temp.si
 	for j := range round.Parties().IDs() {
 		round.ok[j] = true
@@ -56,8 +63,11 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.EDDSAPub.Y(),
 	}
 
-	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
-	if !ok {
+	if round.temp.dom2 != nil {
+		if err := verifyWithDom2(pk.Serialize(), round.data.M, round.data.Signature, round.temp.dom2); err != nil {
+			return round.WrapError(fmt.Errorf("signature verification failed: %v", err))
+		}
+	} else if ok := edwards.Verify(&pk, round.data.M, round.temp.r, s); !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
 	round.end <- round.data
@@ -78,3 +88,26 @@ func (round *finalization) Update() (bool, *tss.Error) {
 func (round *finalization) NextRound() tss.Round {
 	return nil // finished!
 }
+
+// verifyPartialSignatures returns the parties whose s_j does not satisfy s_j * G = R_j + lambda * w_j * G, where lambda
+// is the challenge and w_j * G is the party's public key share X_j scaled by its Lagrange coefficient. This party is
+// checked too, so that every signer reports the same culprits.
+func (round *finalization) verifyPartialSignatures() []*tss.PartyID {
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+	ks := round.key.Ks
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		sj := round.temp.signRound3Messages[j].Content().(*SignRound3Message).UnmarshalS()
+		if sj.Sign() == 0 || sj.Cmp(ec.Params().N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		coef := PrepareForSigning(ec, j, len(ks), big.NewInt(1), ks)
+		expected, err := round.temp.bigRjs[j].Add(round.key.BigXj[j].ScalarMult(modN.Mul(coef, round.temp.lambda)))
+		if err != nil || !crypto.ScalarBaseMult(ec, sj).Equals(expected) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
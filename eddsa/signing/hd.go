@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// DeriveChildPublicKey derives the child of the threshold key at `path` with the chain code saved by keygen, e.g. to
+// show an address without signing. The derivation is the non-hardened scheme of BIP32-Ed25519, see
+// ckd.DeriveEd25519ChildKey; the X and Y of the returned key are on the edwards curve.
+func DeriveChildPublicKey(key keygen.LocalPartySaveData, path []uint32) (*ckd.ExtendedKey, error) {
+	_, child, err := deriveChildKey(key, path)
+	return child, err
+}
+
+// NewLocalPartyWithDerivationPath returns a party signing `msg` with the child of the threshold key at `path`, see
+// DeriveChildPublicKey. The key derivation delta is computed and applied to a copy of `key`, so the save data is left
+// unchanged. SignatureData.PublicKey reports the 32-byte encoding of the child key that the signature verifies against.
+func NewLocalPartyWithDerivationPath(
+	msg []byte,
+	path []uint32,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	delta, child, err := deriveChildKey(key, path)
+	if err != nil {
+		return nil, err
+	}
+	childKey := key
+	childKey.BigXj = append([]*crypto.ECPoint{}, key.BigXj...)
+	keys := []keygen.LocalPartySaveData{childKey}
+	if err := UpdatePublicKeyAndAdjustBigXj(delta, keys, &child.PublicKey, params.EC()); err != nil {
+		return nil, err
+	}
+
+	p := NewLocalPartyWithKDD(new(big.Int).SetBytes(msg), params, keys[0], delta, out, end, len(msg)).(*LocalParty)
+	p.data.PublicKey = edwards.NewPublicKey(child.X, child.Y).Serialize()
+	return p, nil
+}
+
+// ----- //
+
+func deriveChildKey(key keygen.LocalPartySaveData, path []uint32) (*big.Int, *ckd.ExtendedKey, error) {
+	if key.EDDSAPub == nil {
+		return nil, nil, errors.New("the save data has no EDDSAPub")
+	}
+	if len(key.ChainCode) != keygen.ChainCodeLen {
+		return nil, nil, errors.New("the save data has no chain code; the key was generated before keygen produced one")
+	}
+	for _, index := range path {
+		if index >= ckd.HardenedKeyStart {
+			return nil, nil, fmt.Errorf("cannot derive the hardened index %d of a threshold key", index-ckd.HardenedKeyStart)
+		}
+	}
+	master := &ckd.ExtendedKey{
+		PublicKey: ecdsa.PublicKey{Curve: key.EDDSAPub.Curve(), X: key.EDDSAPub.X(), Y: key.EDDSAPub.Y()},
+		ChainCode: key.ChainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+	return ckd.DeriveEd25519ChildKeyFromHierarchy(path, master)
+}
@@ -49,8 +49,10 @@ type (
 		// temp data (thrown away after sign) / round 1
 		wi,
 		m,
+		keyDerivationDelta,
 		ri *big.Int
 		fullBytesLen int
+		dom2         []byte // prefixed to the challenge hash by Ed25519ctx and Ed25519ph, see Options
 		pointRi      *crypto.ECPoint
 		deCommit     cmt.HashDeCommitment
 
@@ -59,13 +61,17 @@ type (
 		si  *[32]byte
 
 		// round 3
-		r *big.Int
+		r      *big.Int
+		lambda *big.Int          // the challenge H(R || A || M)
+		bigRjs []*crypto.ECPoint // the nonce commitment R_j of each party
 
 		ssid      []byte
 		ssidNonce *big.Int
 	}
 )
 
+// NewLocalParty returns a party signing the bytes of `msg`. SignatureData.M holds msg.Bytes(), without leading zero
+// bytes unless the full length is given in `fullBytesLen`; prefer NewLocalPartyWithMessage, which takes the bytes.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -73,12 +79,25 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyWithKDD returns a party with key derivation delta for HD support, see UpdatePublicKeyAndAdjustBigXj
+func NewLocalPartyWithKDD(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
 		BaseParty: new(tss.BaseParty),
 		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		keys:      keygen.BuildLocalSaveDataSubsetWithIndexes(key, params.ShareIndexes()),
 		temp:      localTempData{},
 		data:      &common.SignatureData{},
 		out:       out,
@@ -90,6 +109,7 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
 	if len(fullBytesLen) > 0 {
 		p.temp.fullBytesLen = fullBytesLen[0]
@@ -97,6 +117,7 @@ func NewLocalParty(
 		p.temp.fullBytesLen = 0
 	}
 	p.temp.cjs = make([]*big.Int, partyCount)
+	p.temp.bigRjs = make([]*crypto.ECPoint, partyCount)
 	return p
 }
 
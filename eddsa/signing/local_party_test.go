@@ -7,11 +7,17 @@
 package signing
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
@@ -19,6 +25,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	crypto2 "github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/tweak"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -248,3 +257,474 @@ signing:
 		}
 	}
 }
+
+// The parties sign with new party keys; the shares are located by the share indexes they were created with.
+func TestE2EWithShareIndexes(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	threshold := len(fixtures[0].Ks) / 2
+	keys := fixtures[:threshold+1]
+
+	signPIDs := tss.GenerateTestPartyIDs(len(keys))
+	indexes := make([]*big.Int, len(keys))
+	for j, key := range keys {
+		indexes[j] = key.ShareID
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	msg := big.NewInt(200)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		if !assert.NoError(t, params.SetShareIndexes(indexes)) {
+			return
+		}
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				pk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     keys[0].EDDSAPub.X(),
+					Y:     keys[0].EDDSAPub.Y(),
+				}
+				sig, err := edwards.ParseSignature(parties[0].data.Signature)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.True(t, edwards.Verify(&pk, msg.Bytes(), sig.R, sig.S), "eddsa verify must pass")
+				break signing
+			}
+		}
+	}
+}
+
+// signWithFixtures runs a signing session between t+1 parties of the keygen fixtures, returning the signature sent by
+// each party and the signers' keys
+func signWithFixtures(
+	t *testing.T,
+	newParty func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party,
+) ([]*common.SignatureData, []keygen.LocalPartySaveData) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	return signatures, keys
+}
+
+func TestE2EWithMessageBytes(t *testing.T) {
+	setUp("info")
+
+	msg := []byte{0, 0, 0, 'h', 'e', 'l', 'l', 'o'}
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalPartyWithMessage(msg, params, key, out, end)
+	})
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for _, data := range signatures {
+		assert.Equal(t, msg, data.M, "the message should be output with its leading zero bytes")
+		sig, err := edwards.ParseSignature(data.Signature)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, edwards.Verify(&pk, msg, sig.R, sig.S), "eddsa verify must pass")
+
+		rfc8032, err := data.Ed25519Signature()
+		if assert.NoError(t, err) {
+			assert.Equal(t, data.Signature, rfc8032)
+			assert.True(t, ed25519.Verify(pk.Serialize(), msg, rfc8032), "ed25519 verify must pass")
+		}
+	}
+}
+
+func TestE2EWithDerivationPath(t *testing.T) {
+	setUp("info")
+
+	// the fixtures predate chain codes, so every signer is given the same one
+	chainCode := common.SHA512_256([]byte("chain code"))
+	path := []uint32{44, 501, 0, 7}
+	msg := []byte("derived")
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		key.ChainCode = chainCode
+		P, err := NewLocalPartyWithDerivationPath(msg, path, params, key, out, end)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return P
+	})
+
+	key := keys[0]
+	key.ChainCode = chainCode
+	child, err := DeriveChildPublicKey(key, path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, uint8(len(path)), child.Depth)
+	childPk := edwards.PublicKey{Curve: tss.Edwards(), X: child.X, Y: child.Y}
+	masterPk := edwards.PublicKey{Curve: tss.Edwards(), X: key.EDDSAPub.X(), Y: key.EDDSAPub.Y()}
+
+	for _, data := range signatures {
+		assert.Equal(t, childPk.Serialize(), data.PublicKey)
+		rfc8032, err := data.Ed25519Signature()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, ed25519.Verify(childPk.Serialize(), msg, rfc8032), "ed25519 verify must pass with the derived key")
+		assert.False(t, ed25519.Verify(masterPk.Serialize(), msg, rfc8032), "the signature must not verify with the master key")
+	}
+	assert.True(t, key.EDDSAPub.Equals(keys[0].EDDSAPub), "the save data should be left unchanged")
+}
+
+func TestE2EWithTweaks(t *testing.T) {
+	setUp("info")
+
+	N := tss.Edwards().Params().N
+	msg := []byte("tweaked")
+	payToContract := tweak.Func(func(pub *crypto2.ECPoint) (*big.Int, *big.Int, error) {
+		return big.NewInt(1), common.SHA512_256i(pub.X(), pub.Y(), new(big.Int).SetBytes([]byte("contract"))), nil
+	})
+	tweaks := []tweak.Tweak{
+		tweak.Additive(common.GetRandomPositiveInt(rand.Reader, N)),
+		tweak.Multiplicative(common.GetRandomPositiveInt(rand.Reader, N)),
+		payToContract,
+	}
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		P, err := NewLocalPartyWithTweaks(msg, tweaks, params, key, out, end)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		return P
+	})
+
+	tweaked, err := TweakPublicKey(keys[0], tweaks...)
+	if !assert.NoError(t, err) {
+		return
+	}
+	tweakedPk := edwards.PublicKey{Curve: tss.Edwards(), X: tweaked.X(), Y: tweaked.Y()}
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+	for _, data := range signatures {
+		assert.Equal(t, tweakedPk.Serialize(), data.PublicKey)
+		rfc8032, err := data.Ed25519Signature()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.True(t, ed25519.Verify(tweakedPk.Serialize(), msg, rfc8032), "ed25519 verify must pass with the tweaked key")
+		assert.False(t, ed25519.Verify(pk.Serialize(), msg, rfc8032), "the signature must not verify with the key")
+	}
+}
+
+func TestE2EWithOptions(t *testing.T) {
+	setUp("info")
+
+	payload := []byte("a large payload")
+	digest := sha512.Sum512(payload)
+	for _, tc := range []struct {
+		name string
+		msg  []byte
+		opts *Options
+	}{
+		{"Ed25519ph", digest[:], &Options{Hash: crypto.SHA512}},
+		{"Ed25519ph with context", digest[:], &Options{Hash: crypto.SHA512, Context: "swap"}},
+		{"Ed25519ctx", payload, &Options{Context: "swap"}},
+	} {
+		signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			P, err := NewLocalPartyWithOptions(tc.msg, tc.opts, params, key, out, end)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			return P
+		})
+		pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+		for _, data := range signatures {
+			assert.Equal(t, tc.msg, data.M, tc.name)
+			rfc8032, err := data.Ed25519Signature()
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.NoError(t, VerifyWithOptions(pk.Serialize(), tc.msg, rfc8032, tc.opts), tc.name)
+			assert.Error(t, VerifyWithOptions(pk.Serialize(), tc.msg, rfc8032, &Options{Context: "other"}), tc.name)
+			assert.False(t, ed25519.Verify(pk.Serialize(), tc.msg, rfc8032), "%s must not verify as pure Ed25519", tc.name)
+		}
+	}
+}
+
+func TestOptionsErrors(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], 1, 0)
+	newParty := func(msg []byte, opts *Options) error {
+		_, err := NewLocalPartyWithOptions(msg, opts, params, keys[0], nil, nil)
+		return err
+	}
+	digest := sha512.Sum512([]byte("payload"))
+	assert.Error(t, newParty([]byte("payload"), &Options{Hash: crypto.SHA512}), "Ed25519ph signs a SHA-512 digest")
+	assert.Error(t, newParty(digest[:], &Options{Hash: crypto.SHA256}), "Ed25519ph uses SHA-512")
+	assert.Error(t, newParty(digest[:], &Options{Context: strings.Repeat("c", MaxContextLen+1)}), "the context is too long")
+	assert.NoError(t, newParty(digest[:], &Options{Context: strings.Repeat("c", MaxContextLen)}))
+	assert.NoError(t, newParty(digest[:], nil), "nil options select Ed25519")
+}
+
+func TestDerivationPathErrors(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := keys[0]
+	_, err = DeriveChildPublicKey(key, []uint32{1})
+	assert.Error(t, err, "the fixture has no chain code")
+
+	key.ChainCode = common.SHA512_256([]byte("chain code"))
+	_, err = DeriveChildPublicKey(key, []uint32{ckd.HardenedKeyStart + 44})
+	assert.Error(t, err, "hardened derivation is impossible")
+
+	master, err := DeriveChildPublicKey(key, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, key.EDDSAPub.X().Cmp(master.X))
+		assert.Equal(t, 0, key.EDDSAPub.Y().Cmp(master.Y))
+	}
+}
+
+func TestE2EWithSignerFallback(t *testing.T) {
+	setUp("info")
+
+	first, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	fixtures, holders, err := keygen.LoadKeygenTestFixtures(len(first[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures) / 2
+	keys := make(map[string]keygen.LocalPartySaveData, len(fixtures))
+	for _, fixture := range fixtures {
+		keys[fixture.ShareID.String()] = fixture
+	}
+	// the first holder would be picked first, but never answers
+	silent := holders[0]
+
+	msg := []byte("fallback")
+	sessions := 0
+	sigData, excluded, err := tss.SignWithFallback(holders, threshold, func(signers tss.SortedPartyIDs) (*common.SignatureData, *tss.Error) {
+		sessions++
+		p2pCtx := tss.NewPeerContext(signers)
+		parties := make([]tss.Party, len(signers))
+		errCh := make(chan *tss.Error, 2*len(signers))
+		outCh := make(chan tss.Message, len(signers))
+		endCh := make(chan *common.SignatureData, len(signers))
+		done := make(chan struct{})
+		defer close(done)
+
+		live := 0
+		for i, signer := range signers {
+			if signer.KeyInt().Cmp(silent.KeyInt()) == 0 {
+				continue
+			}
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, signer, len(signers), threshold)
+			P := NewLocalPartyWithMessage(msg, params, keys[signer.KeyInt().String()], outCh, endCh)
+			parties[i] = P
+			live++
+			go func(timeoutCh <-chan *tss.Error) {
+				if err := <-timeoutCh; err != nil {
+					errCh <- err
+				}
+			}(tss.WatchRounds(P, 500*time.Millisecond, done))
+			go func(P tss.Party) {
+				if err := P.Start(); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		var sigData *common.SignatureData
+		for ended := 0; ended < live; {
+			select {
+			case err := <-errCh:
+				return nil, err
+			case msg := <-outCh:
+				for _, P := range parties {
+					if P == nil || P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					if dest := msg.GetTo(); dest == nil || dest[0].Index == P.PartyID().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+			case sigData = <-endCh:
+				ended++
+			}
+		}
+		return sigData, nil
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 2, sessions, "the second session should exclude the silent holder")
+	if assert.Len(t, excluded, 1) {
+		assert.Equal(t, silent, excluded[0])
+	}
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: fixtures[0].EDDSAPub.X(), Y: fixtures[0].EDDSAPub.Y()}
+	rfc8032, err := sigData.Ed25519Signature()
+	if assert.NoError(t, err) {
+		assert.True(t, ed25519.Verify(pk.Serialize(), msg, rfc8032), "ed25519 verify must pass")
+	}
+
+	_, excluded, err = tss.SignWithFallback(holders, threshold, func(signers tss.SortedPartyIDs) (*common.SignatureData, *tss.Error) {
+		return nil, tss.NewError(tss.ErrRoundTimeout, TaskName, 1, signers[0], signers[0])
+	})
+	assert.Error(t, err, "signing should stop once fewer than t+1 holders are left")
+	assert.Len(t, excluded, len(holders)-threshold)
+}
+
+func TestE2EPartialSignatureCulprit(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	cheater := 1
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalPartyWithMessage([]byte("partial"), params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	for len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			// the cheater's w_i is wrong, so it broadcasts an s_i that does not match its R_i and X_i
+			if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound1Message); ok && msg.GetFrom().Index == cheater {
+				P := parties[cheater].(*LocalParty)
+				P.temp.wi = new(big.Int).Add(P.temp.wi, big.NewInt(1))
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "signing should not succeed")
+		}
+	}
+
+	for _, err := range errs {
+		assert.Equal(t, 4, err.Round(), err.Error())
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+		}
+	}
+}
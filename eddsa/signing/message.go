@@ -0,0 +1,27 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// NewLocalPartyWithMessage returns a party signing `msg` as is; EdDSA hashes the message itself, so it must not be
+// hashed beforehand. SignatureData.M holds the message exactly as given, leading zero bytes included.
+func NewLocalPartyWithMessage(
+	msg []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	return NewLocalParty(new(big.Int).SetBytes(msg), params, key, out, end, len(msg))
+}
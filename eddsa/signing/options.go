@@ -0,0 +1,146 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	tsscrypto "github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// the prefix of dom2(phflag, context) in RFC 8032, section 5.1
+	dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+	// MaxContextLen is the maximum length of the context string of Ed25519ctx and Ed25519ph
+	MaxContextLen = 255
+)
+
+// Options selects the RFC 8032 variant of a signing session, as crypto/ed25519.Options does from Go 1.20:
+//   - Ed25519 when Hash is zero and Context is empty;
+//   - Ed25519ctx when Hash is zero and Context is not empty;
+//   - Ed25519ph when Hash is crypto.SHA512, with an optional Context. The message is then the SHA-512 digest of the
+//     payload, so that large payloads need not be given to the signers.
+type Options struct {
+	Hash    crypto.Hash
+	Context string
+}
+
+// NewLocalPartyWithOptions returns a party signing `msg` with the variant of Ed25519 selected by `opts`, see
+// NewLocalPartyWithMessage. The signature verifies with crypto/ed25519.VerifyWithOptions, or with VerifyWithOptions on
+// earlier versions of Go.
+func NewLocalPartyWithOptions(
+	msg []byte,
+	opts *Options,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	dom2, err := opts.dom2(msg)
+	if err != nil {
+		return nil, err
+	}
+	p := NewLocalPartyWithMessage(msg, params, key, out, end).(*LocalParty)
+	p.temp.dom2 = dom2
+	return p, nil
+}
+
+// VerifyWithOptions reports whether `sig` is a valid signature of `msg` by the 32-byte public key `pub`, with the
+// variant of Ed25519 selected by `opts`. It is the check of crypto/ed25519.VerifyWithOptions, for earlier versions of
+// Go: [s]B = R + [k]A with k = SHA-512(dom2(phflag, context) || R || A || M).
+func VerifyWithOptions(pub, msg, sig []byte, opts *Options) error {
+	dom2, err := opts.dom2(msg)
+	if err != nil {
+		return err
+	}
+	return verifyWithDom2(pub, msg, sig, dom2)
+}
+
+// ----- //
+
+func verifyWithDom2(pub, msg, sig, dom2 []byte) error {
+	if len(sig) != 64 {
+		return errors.New("the signature must be 64 bytes")
+	}
+	ec := tss.Edwards()
+	A, err := edwards.ParsePubKey(pub)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	R, err := edwards.ParsePubKey(sig[:32])
+	if err != nil {
+		return fmt.Errorf("invalid signature R: %v", err)
+	}
+	var sBytes [32]byte
+	copy(sBytes[:], sig[32:])
+	s := encodedBytesToBigInt(&sBytes)
+	if s.Cmp(ec.Params().N) >= 0 {
+		return errors.New("the signature S is not reduced")
+	}
+
+	h := sha512.New()
+	h.Write(dom2)
+	h.Write(sig[:32])
+	h.Write(pub)
+	h.Write(msg)
+	k := new(big.Int).Mod(encodedBytesToBigInt64(h.Sum(nil)), ec.Params().N)
+
+	bigA := tsscrypto.NewECPointNoCurveCheck(ec, A.X, A.Y)
+	expected, err := tsscrypto.NewECPointNoCurveCheck(ec, R.X, R.Y).Add(bigA.ScalarMult(k))
+	if err != nil || !tsscrypto.ScalarBaseMult(ec, s).Equals(expected) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// dom2 returns dom2(phflag, context) for the variant selected by `o`, or nil for Ed25519, after checking that it can
+// sign `msg`
+func (o *Options) dom2(msg []byte) ([]byte, error) {
+	if o == nil {
+		return nil, nil
+	}
+	if len(o.Context) > MaxContextLen {
+		return nil, fmt.Errorf("the context must be at most %d bytes", MaxContextLen)
+	}
+	var phflag byte
+	switch o.Hash {
+	case crypto.SHA512:
+		if len(msg) != sha512.Size {
+			return nil, errors.New("an Ed25519ph message must be the 64-byte SHA-512 digest of the payload")
+		}
+		phflag = 1
+	case crypto.Hash(0):
+		if o.Context == "" {
+			return nil, nil
+		}
+	default:
+		return nil, errors.New("Ed25519ph requires crypto.SHA512 as the hash")
+	}
+	dom2 := make([]byte, 0, len(dom2Prefix)+2+len(o.Context))
+	dom2 = append(dom2, dom2Prefix...)
+	dom2 = append(dom2, phflag, byte(len(o.Context)))
+	return append(dom2, o.Context...), nil
+}
+
+// encodedBytesToBigInt64 reads a 64-byte little endian hash as an integer
+func encodedBytesToBigInt64(bz []byte) *big.Int {
+	be := make([]byte, len(bz))
+	for i := range bz {
+		be[len(bz)-1-i] = bz[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+//go:build go1.20
+// +build go1.20
+
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// crypto/ed25519 supports Ed25519ph and Ed25519ctx from Go 1.20
+func TestOptionsWithStdlib(t *testing.T) {
+	setUp("info")
+
+	digest := sha512.Sum512([]byte("a large payload"))
+	opts := []*Options{{Hash: crypto.SHA512}, {Hash: crypto.SHA512, Context: "swap"}, {Context: "swap"}}
+	stdOpts := []*ed25519.Options{{Hash: crypto.SHA512}, {Hash: crypto.SHA512, Context: "swap"}, {Context: "swap"}}
+
+	// a signature by crypto/ed25519 verifies with VerifyWithOptions
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for i := range opts {
+		sig, err := priv.Sign(rand.Reader, digest[:], stdOpts[i])
+		if assert.NoError(t, err) {
+			assert.NoError(t, VerifyWithOptions(pub, digest[:], sig, opts[i]))
+		}
+	}
+
+	// and a threshold signature with crypto/ed25519.VerifyWithOptions
+	for i := range opts {
+		signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			P, err := NewLocalPartyWithOptions(digest[:], opts[i], params, key, out, end)
+			if !assert.NoError(t, err) {
+				t.FailNow()
+			}
+			return P
+		})
+		pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+		rfc8032, err := signatures[0].Ed25519Signature()
+		if assert.NoError(t, err) {
+			assert.NoError(t, ed25519.VerifyWithOptions(pk.Serialize(), digest[:], rfc8032, stdOpts[i]))
+		}
+	}
+}
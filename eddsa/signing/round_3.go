@@ -35,6 +35,7 @@ func (round *round3) Start() *tss.Error {
 
 	// 2-6. compute R
 	i := round.PartyID().Index
+	round.temp.bigRjs[i] = round.temp.pointRi
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
@@ -66,6 +67,7 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(errors.New("failed to prove Rj"), Pj)
 		}
 
+		round.temp.bigRjs[j] = Rj
 		extendedRj := ecPointToExtendedElement(round.Params().EC(), Rj.X(), Rj.Y(), round.Rand())
 		R = addExtendedElements(R, extendedRj)
 	}
@@ -75,9 +77,10 @@ func (round *round3) Start() *tss.Error {
 	R.ToBytes(&encodedR)
 	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
 
-	// h = hash512(k || A || M)
+	// h = hash512(dom2 || R || A || M), where dom2 is empty for pure Ed25519
 	h := sha512.New()
 	h.Reset()
+	h.Write(round.temp.dom2)
 	h.Write(encodedR[:])
 	h.Write(encodedPubKey[:])
 	if round.temp.fullBytesLen == 0 {
@@ -100,6 +103,7 @@ func (round *round3) Start() *tss.Error {
 	// 9. store r3 message pieces
 	round.temp.si = &localS
 	round.temp.r = encodedBytesToBigInt(&encodedR)
+	round.temp.lambda = encodedBytesToBigInt(&lambdaReduced)
 
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
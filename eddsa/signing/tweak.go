@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/tweak"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// TweakPublicKey returns the threshold key of `key` tweaked by `tweaks` in order, which NewLocalPartyWithTweaks signs
+// with, e.g. to show an address without signing
+func TweakPublicKey(key keygen.LocalPartySaveData, tweaks ...tweak.Tweak) (*crypto.ECPoint, error) {
+	affine, err := tweak.Compute(key.EDDSAPub, tweaks...)
+	if err != nil {
+		return nil, err
+	}
+	return affine.PublicKey, nil
+}
+
+// NewLocalPartyWithTweaks returns a party signing `msg` with the threshold key tweaked by `tweaks` in order, see
+// TweakPublicKey and NewLocalPartyWithMessage. The tweaks are applied to a copy of `key`, so the save data is left
+// unchanged. SignatureData.PublicKey reports the 32-byte encoding of the tweaked key that the signature verifies against.
+func NewLocalPartyWithTweaks(
+	msg []byte,
+	tweaks []tweak.Tweak,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	tweaked, err := tweakKey(key, tweaks)
+	if err != nil {
+		return nil, err
+	}
+	p := NewLocalPartyWithMessage(msg, params, tweaked, out, end).(*LocalParty)
+	p.data.PublicKey = edwards.NewPublicKey(tweaked.EDDSAPub.X(), tweaked.EDDSAPub.Y()).Serialize()
+	return p, nil
+}
+
+// ----- //
+
+// tweakKey returns a copy of `key` with the tweaked public key, secret share and public shares
+func tweakKey(key keygen.LocalPartySaveData, tweaks []tweak.Tweak) (keygen.LocalPartySaveData, error) {
+	affine, err := tweak.Compute(key.EDDSAPub, tweaks...)
+	if err != nil {
+		return key, err
+	}
+	tweaked := key
+	tweaked.EDDSAPub = affine.PublicKey
+	tweaked.Xi = affine.Share(key.Xi)
+	tweaked.BigXj = make([]*crypto.ECPoint, len(key.BigXj))
+	for j, bigXj := range key.BigXj {
+		if tweaked.BigXj[j], err = affine.PublicShare(bigXj); err != nil {
+			return key, err
+		}
+	}
+	return tweaked, nil
+}
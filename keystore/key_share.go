@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// SealECDSA encrypts the save data of an ECDSA key share with the passphrase.
+// The party ID and threshold are recorded in the authenticated metadata alongside the public key and curve.
+func SealECDSA(save *ecdsakeygen.LocalPartySaveData, partyID *tss.PartyID, threshold int, passphrase []byte, params ScryptParams) ([]byte, error) {
+	meta, err := newMetadata(AlgorithmECDSA, save.ECDSAPub, partyID, threshold, len(save.Ks))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := ecdsakeygen.MarshalSaveData(save)
+	if err != nil {
+		return nil, err
+	}
+	return seal(plaintext, meta, passphrase, params)
+}
+
+// OpenECDSA decrypts a keystore written by SealECDSA and checks the save data against the metadata and for self-consistency
+func OpenECDSA(keystore, passphrase []byte) (*ecdsakeygen.LocalPartySaveData, *Metadata, error) {
+	meta, plaintext, err := open(keystore, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta.GetAlgorithm() != AlgorithmECDSA {
+		return nil, nil, fmt.Errorf("the keystore holds an %q key share, not %q", meta.GetAlgorithm(), AlgorithmECDSA)
+	}
+	save, err := ecdsakeygen.UnmarshalSaveData(plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = meta.check(save.ECDSAPub, len(save.Ks)); err != nil {
+		return nil, nil, err
+	}
+	if err = save.Validate(int(meta.GetThreshold())); err != nil {
+		return nil, nil, err
+	}
+	return save, meta, nil
+}
+
+// SealEDDSA encrypts the save data of an EDDSA key share with the passphrase.
+// The party ID and threshold are recorded in the authenticated metadata alongside the public key and curve.
+func SealEDDSA(save *eddsakeygen.LocalPartySaveData, partyID *tss.PartyID, threshold int, passphrase []byte, params ScryptParams) ([]byte, error) {
+	meta, err := newMetadata(AlgorithmEDDSA, save.EDDSAPub, partyID, threshold, len(save.Ks))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := eddsakeygen.MarshalSaveData(save)
+	if err != nil {
+		return nil, err
+	}
+	return seal(plaintext, meta, passphrase, params)
+}
+
+// OpenEDDSA decrypts a keystore written by SealEDDSA and checks the save data against the metadata and for self-consistency
+func OpenEDDSA(keystore, passphrase []byte) (*eddsakeygen.LocalPartySaveData, *Metadata, error) {
+	meta, plaintext, err := open(keystore, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta.GetAlgorithm() != AlgorithmEDDSA {
+		return nil, nil, fmt.Errorf("the keystore holds an %q key share, not %q", meta.GetAlgorithm(), AlgorithmEDDSA)
+	}
+	save, err := eddsakeygen.UnmarshalSaveData(plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = meta.check(save.EDDSAPub, len(save.Ks)); err != nil {
+		return nil, nil, err
+	}
+	if err = save.Validate(int(meta.GetThreshold())); err != nil {
+		return nil, nil, err
+	}
+	return save, meta, nil
+}
+
+// ----- //
+
+func newMetadata(algorithm string, pub *crypto.ECPoint, partyID *tss.PartyID, threshold, partyCount int) (*Metadata, error) {
+	if pub == nil {
+		return nil, errors.New("the save data has no public key")
+	}
+	if partyID == nil || !partyID.ValidateBasic() {
+		return nil, errors.New("a valid party ID is required")
+	}
+	if threshold < 1 || partyCount <= threshold {
+		return nil, fmt.Errorf("invalid threshold %d for a key shared by %d parties", threshold, partyCount)
+	}
+	ecName, ok := tss.GetCurveName(pub.Curve())
+	if !ok {
+		return nil, fmt.Errorf("cannot find %T name in curve registry", pub.Curve())
+	}
+	return &Metadata{
+		Algorithm:    algorithm,
+		Curve:        string(ecName),
+		PublicKeyX:   pub.X().Bytes(),
+		PublicKeyY:   pub.Y().Bytes(),
+		PartyId:      partyID.Id,
+		PartyMoniker: partyID.Moniker,
+		PartyKey:     partyID.Key,
+		Threshold:    uint32(threshold),
+		PartyCount:   uint32(partyCount),
+	}, nil
+}
+
+// check ensures the decrypted save data is the key share described by the metadata
+func (meta *Metadata) check(pub *crypto.ECPoint, partyCount int) error {
+	ecName, ok := tss.GetCurveName(pub.Curve())
+	if !ok || string(ecName) != meta.GetCurve() {
+		return fmt.Errorf("the save data curve does not match the keystore curve %q", meta.GetCurve())
+	}
+	if !bytes.Equal(pub.X().Bytes(), meta.GetPublicKeyX()) || !bytes.Equal(pub.Y().Bytes(), meta.GetPublicKeyY()) {
+		return errors.New("the save data public key does not match the keystore metadata")
+	}
+	if partyCount != int(meta.GetPartyCount()) {
+		return fmt.Errorf("the save data holds %d parties but the keystore metadata %d", partyCount, meta.GetPartyCount())
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keystore implements an encrypted at-rest container for the save data produced by keygen and re-sharing.
+// The save data is serialized in its versioned protobuf format and encrypted with XChaCha20-Poly1305 under a key
+// derived from a passphrase with scrypt. Metadata identifying the key share is stored in cleartext and authenticated.
+package keystore
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Version is the keystore format version written by this package
+	Version = 1
+
+	AlgorithmECDSA = "ecdsa"
+	AlgorithmEDDSA = "eddsa"
+
+	cipherXChaCha20Poly1305 = "xchacha20-poly1305"
+	saltLen                 = 32
+
+	// upper bounds on the scrypt parameters accepted when opening a keystore, so that a tampered header cannot exhaust
+	// memory: scrypt allocates 128*N*r bytes, which is capped at 1GiB, four times StandardScryptParams
+	maxScryptN      = 1 << 22
+	maxScryptR      = 32
+	maxScryptP      = 16
+	maxScryptMemory = 1 << 30
+)
+
+type (
+	// ScryptParams are the cost parameters of the scrypt key derivation
+	ScryptParams struct {
+		N, R, P int
+	}
+)
+
+var (
+	// StandardScryptParams should be used for key shares in production; deriving the key takes around a second and 256MB of memory
+	StandardScryptParams = ScryptParams{N: 1 << 18, R: 8, P: 1}
+	// LightScryptParams are intended for tests and constrained devices only
+	LightScryptParams = ScryptParams{N: 1 << 12, R: 8, P: 6}
+
+	// ErrDecrypt is returned when the passphrase is wrong or the keystore has been tampered with
+	ErrDecrypt = errors.New("could not decrypt the key share: wrong passphrase or corrupted keystore")
+	// ErrUnknownVersion is returned when the keystore was written by a newer version of this package
+	ErrUnknownVersion = errors.New("unknown keystore version")
+)
+
+// ReadMetadata returns the authenticated cleartext metadata of a keystore without decrypting it.
+// The metadata is only verified to be authentic when the keystore is opened with the passphrase.
+func ReadMetadata(keystore []byte) (*Metadata, error) {
+	_, header, err := parse(keystore)
+	if err != nil {
+		return nil, err
+	}
+	return header.GetMetadata(), nil
+}
+
+// ChangePassphrase re-encrypts a keystore under a new passphrase with a fresh salt and nonce.
+// The metadata and the encrypted save data are carried over unchanged.
+func ChangePassphrase(keystore, oldPassphrase, newPassphrase []byte, params ScryptParams) ([]byte, error) {
+	meta, plaintext, err := open(keystore, oldPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return seal(plaintext, meta, newPassphrase, params)
+}
+
+// ----- //
+
+func seal(plaintext []byte, meta *Metadata, passphrase []byte, params ScryptParams) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	header := &Header{
+		Version:  Version,
+		Metadata: meta,
+		Salt:     salt,
+		ScryptN:  uint32(params.N),
+		ScryptR:  uint32(params.R),
+		ScryptP:  uint32(params.P),
+		Cipher:   cipherXChaCha20Poly1305,
+		Nonce:    nonce,
+	}
+	headerBz, err := proto.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, header)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&EncryptedKeyShare{
+		Header:     headerBz,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, headerBz),
+	})
+}
+
+func open(keystore, passphrase []byte) (*Metadata, []byte, error) {
+	ks, header, err := parse(keystore)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := newAEAD(passphrase, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := aead.Open(nil, header.GetNonce(), ks.GetCiphertext(), ks.GetHeader())
+	if err != nil {
+		return nil, nil, ErrDecrypt
+	}
+	return header.GetMetadata(), plaintext, nil
+}
+
+func parse(keystore []byte) (*EncryptedKeyShare, *Header, error) {
+	ks := new(EncryptedKeyShare)
+	if err := proto.Unmarshal(keystore, ks); err != nil {
+		return nil, nil, err
+	}
+	header := new(Header)
+	if err := proto.Unmarshal(ks.GetHeader(), header); err != nil {
+		return nil, nil, err
+	}
+	switch v := header.GetVersion(); {
+	case v == 0:
+		return nil, nil, errors.New("the keystore has no version")
+	case v > Version:
+		return nil, nil, fmt.Errorf("%w: keystore version %d, latest supported %d", ErrUnknownVersion, v, Version)
+	}
+	if header.GetCipher() != cipherXChaCha20Poly1305 {
+		return nil, nil, fmt.Errorf("unsupported keystore cipher %q", header.GetCipher())
+	}
+	if header.GetMetadata() == nil {
+		return nil, nil, errors.New("the keystore has no metadata")
+	}
+	return ks, header, nil
+}
+
+func newAEAD(passphrase []byte, header *Header) (cipher.AEAD, error) {
+	params := ScryptParams{N: int(header.GetScryptN()), R: int(header.GetScryptR()), P: int(header.GetScryptP())}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	if len(header.GetSalt()) != saltLen || len(header.GetNonce()) != chacha20poly1305.NonceSizeX {
+		return nil, errors.New("the keystore salt or nonce has an unexpected length")
+	}
+	key, err := scrypt.Key(passphrase, header.GetSalt(), params.N, params.R, params.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+func (params ScryptParams) validate() error {
+	if params.N <= 1 || params.N&(params.N-1) != 0 || params.N > maxScryptN {
+		return fmt.Errorf("scrypt N must be a power of two between 2 and %d", maxScryptN)
+	}
+	if params.R < 1 || params.R > maxScryptR || params.P < 1 || params.P > maxScryptP {
+		return fmt.Errorf("scrypt r and p must be in [1, %d] and [1, %d]", maxScryptR, maxScryptP)
+	}
+	if 128*int64(params.N)*int64(params.R) > maxScryptMemory {
+		return fmt.Errorf("scrypt N and r must not need more than %d bytes of memory (128*N*r)", maxScryptMemory)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+)
+
+var (
+	passphrase = []byte("correct horse battery staple")
+)
+
+func TestSealOpenECDSA(t *testing.T) {
+	fixtures, pIDs, err := ecdsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keystore, err := SealECDSA(&fixtures[0], pIDs[0], len(fixtures[0].Ks)/2, passphrase, LightScryptParams)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	meta, err := ReadMetadata(keystore)
+	assert.NoError(t, err)
+	assert.Equal(t, AlgorithmECDSA, meta.GetAlgorithm())
+	assert.Equal(t, pIDs[0].Id, meta.GetPartyId())
+	assert.Equal(t, uint32(len(fixtures[0].Ks)/2), meta.GetThreshold())
+	assert.Equal(t, uint32(len(fixtures[0].Ks)), meta.GetPartyCount())
+
+	save, _, err := OpenECDSA(keystore, passphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, fixtures[0].Xi, save.Xi)
+	assert.True(t, fixtures[0].ECDSAPub.Equals(save.ECDSAPub))
+
+	_, _, err = OpenECDSA(keystore, []byte("wrong"))
+	assert.True(t, errors.Is(err, ErrDecrypt))
+	_, _, err = OpenEDDSA(keystore, passphrase)
+	assert.Error(t, err, "an ECDSA keystore should not open as EDDSA")
+}
+
+func TestSealOpenEDDSA(t *testing.T) {
+	fixtures, pIDs, err := eddsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keystore, err := SealEDDSA(&fixtures[0], pIDs[0], len(fixtures[0].Ks)/2, passphrase, LightScryptParams)
+	if !assert.NoError(t, err) {
+		return
+	}
+	save, meta, err := OpenEDDSA(keystore, passphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, AlgorithmEDDSA, meta.GetAlgorithm())
+	assert.Equal(t, fixtures[0].Xi, save.Xi)
+	assert.True(t, fixtures[0].EDDSAPub.Equals(save.EDDSAPub))
+}
+
+func TestTamperedMetadata(t *testing.T) {
+	fixtures, pIDs, err := eddsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keystore, err := SealEDDSA(&fixtures[0], pIDs[0], len(fixtures[0].Ks)/2, passphrase, LightScryptParams)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ks, header, err := parse(keystore)
+	if !assert.NoError(t, err) {
+		return
+	}
+	header.Metadata.Threshold++
+	ks.Header, err = proto.Marshal(header)
+	assert.NoError(t, err)
+	tampered, err := proto.Marshal(ks)
+	assert.NoError(t, err)
+
+	_, _, err = OpenEDDSA(tampered, passphrase)
+	assert.True(t, errors.Is(err, ErrDecrypt), "tampered metadata should fail authentication")
+}
+
+func TestChangePassphrase(t *testing.T) {
+	fixtures, pIDs, err := eddsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keystore, err := SealEDDSA(&fixtures[0], pIDs[0], len(fixtures[0].Ks)/2, passphrase, LightScryptParams)
+	if !assert.NoError(t, err) {
+		return
+	}
+	newPassphrase := []byte("new passphrase")
+	changed, err := ChangePassphrase(keystore, passphrase, newPassphrase, LightScryptParams)
+	assert.NoError(t, err)
+
+	_, _, err = OpenEDDSA(changed, passphrase)
+	assert.True(t, errors.Is(err, ErrDecrypt))
+	save, _, err := OpenEDDSA(changed, newPassphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, fixtures[0].Xi, save.Xi)
+}
+
+func TestScryptParamsBounds(t *testing.T) {
+	assert.NoError(t, StandardScryptParams.validate())
+	assert.NoError(t, LightScryptParams.validate())
+	assert.NoError(t, ScryptParams{N: 1 << 20, R: 8, P: 1}.validate(), "1GiB is allowed")
+	assert.Error(t, ScryptParams{N: 1 << 22, R: 32, P: 1}.validate(), "16GiB exceeds the memory bound")
+	assert.Error(t, ScryptParams{N: 1 << 21, R: 8, P: 1}.validate(), "2GiB exceeds the memory bound")
+
+	// a header demanding too much memory is rejected before scrypt runs
+	fixtures, pIDs, err := eddsakeygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	keystore, err := SealEDDSA(&fixtures[0], pIDs[0], len(fixtures[0].Ks)/2, passphrase, LightScryptParams)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ks, header, err := parse(keystore)
+	if !assert.NoError(t, err) {
+		return
+	}
+	header.ScryptN, header.ScryptR = 1<<22, 32
+	ks.Header, err = proto.Marshal(header)
+	assert.NoError(t, err)
+	tampered, err := proto.Marshal(ks)
+	assert.NoError(t, err)
+	_, _, err = OpenEDDSA(tampered, passphrase)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "memory")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// check each z_j before aggregating, so that an invalid one is attributed to its sender
+	if culprits := round.verifyPartialSignatures(); len(culprits) > 0 {
+		return round.WrapError(errors.New("partial signature verification failed"), culprits...)
+	}
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	s := big.NewInt(0)
+	for j, msg := range round.temp.signRound2Messages {
+		round.ok[j] = true
+		s = modN.Add(s, msg.Content().(*SignRound2Message).UnmarshalZ())
+	}
+
+	// save the signature for final output
+	round.data.Signature = append(xOnlyBytes(round.temp.bigR), scalarBytes(s)...)
+	round.data.R = round.temp.bigR.X().Bytes()
+	round.data.S = s.Bytes()
+	round.data.M = round.temp.m
+
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	pk, err := schnorr.ParsePubKey(round.data.PublicKey)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if !sig.Verify(round.data.M, pk) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// verifyPartialSignatures returns the parties whose z_j does not satisfy z_j * G = R_j + lambda_j * c * X_j, where R_j
+// is the party's nonce commitment, negated along with R, c is the challenge and X_j is the party's share of the tweaked
+// key. This party is checked too, so that every signer reports the same culprits.
+func (round *finalization) verifyPartialSignatures() []*tss.PartyID {
+	ec := round.Params().EC()
+	modN := common.ModInt(ec.Params().N)
+	ks := round.key.Ks
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		zj := round.temp.signRound2Messages[j].Content().(*SignRound2Message).UnmarshalZ()
+		if zj.Cmp(ec.Params().N) >= 0 {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		Rj, err := round.nonceCommitment(j)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if round.temp.oddR {
+			Rj = negatePoint(Rj)
+		}
+		coef := PrepareForSigning(ec, j, len(ks), big.NewInt(1), ks)
+		expected, err := Rj.Add(round.temp.bigXj[j].ScalarMult(modN.Mul(coef, round.temp.c)))
+		if err != nil || !crypto.ScalarBaseMult(ec, zj).Equals(expected) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// MessageLen is the byte length of the messages signed with BIP-340, such as Taproot signature hashes
+	MessageLen = 32
+	// XOnlyKeyLen is the byte length of a BIP-340 public key, the X coordinate of a point with an even Y
+	XOnlyKeyLen = 32
+	// SignatureLen is the byte length of a BIP-340 signature, R.x || s
+	SignatureLen = 64
+)
+
+type (
+	// keyTweak maps the threshold secret key x, and each of its shares, to a*x + b; a*x + b is the secret key of the
+	// x-only public key `pub` that a session signs for. `a` is -1 or 1 and takes care of the BIP-340 convention that a
+	// public key has an even Y, `b` is the Taproot tweak.
+	keyTweak struct {
+		a, b *big.Int
+		pub  *crypto.ECPoint
+	}
+)
+
+// XOnlyPublicKey returns the BIP-340 public key of the threshold key in `key`, the ECDSA save data of keygen. The
+// signatures of NewLocalParty verify against it.
+func XOnlyPublicKey(key keygen.LocalPartySaveData) ([]byte, error) {
+	kt, err := newKeyTweak(key.ECDSAPub, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return xOnlyBytes(kt.pub), nil
+}
+
+// TaprootOutputKey returns the BIP-341 output key that commits to the threshold key in `key`, as the internal key, and
+// to the script tree with the root `merkleRoot`. Use an empty `merkleRoot` for a key with no script path, as in BIP-86.
+// The signatures of NewLocalPartyWithTaprootTweak verify against it.
+func TaprootOutputKey(key keygen.LocalPartySaveData, merkleRoot []byte) ([]byte, error) {
+	kt, err := newKeyTweak(key.ECDSAPub, true, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return xOnlyBytes(kt.pub), nil
+}
+
+// ----- //
+
+func newKeyTweak(pub *crypto.ECPoint, taproot bool, merkleRoot []byte) (*keyTweak, error) {
+	if pub == nil || !tss.SameCurve(pub.Curve(), tss.S256()) {
+		return nil, errors.New("BIP-340 signatures need a secp256k1 key")
+	}
+	if len(merkleRoot) != 0 && len(merkleRoot) != 32 {
+		return nil, fmt.Errorf("the Taproot merkle root must be 32 bytes, got %d", len(merkleRoot))
+	}
+	ec := pub.Curve()
+	modN := common.ModInt(ec.Params().N)
+	kt := &keyTweak{a: big.NewInt(1), b: big.NewInt(0), pub: pub}
+	if hasOddY(kt.pub) {
+		kt.a, kt.pub = modN.Sub(big.NewInt(0), kt.a), negatePoint(kt.pub)
+	}
+	if !taproot {
+		return kt, nil
+	}
+
+	// BIP-341: Q = P + H_TapTweak(P.x || merkle root)*G, with P the internal key with an even Y
+	t := new(big.Int).SetBytes(taggedHash(tagTapTweak, xOnlyBytes(kt.pub), merkleRoot))
+	if t.Cmp(ec.Params().N) >= 0 {
+		return nil, errors.New("the Taproot tweak is not below the curve order")
+	}
+	Q, err := kt.pub.Add(crypto.ScalarBaseMult(ec, t))
+	if err != nil {
+		return nil, fmt.Errorf("the Taproot output key is invalid: %v", err)
+	}
+	kt.b, kt.pub = t, Q
+	if hasOddY(kt.pub) {
+		kt.a, kt.b, kt.pub = modN.Sub(big.NewInt(0), kt.a), modN.Sub(big.NewInt(0), kt.b), negatePoint(kt.pub)
+	}
+	return kt, nil
+}
+
+// share maps the share x_i of the threshold key to a share of the tweaked key; Lagrange coefficients sum to 1, so the
+// shares of a*x + b are a*x_i + b
+func (kt *keyTweak) share(xi *big.Int) *big.Int {
+	modN := common.ModInt(kt.pub.Curve().Params().N)
+	return modN.Add(modN.Mul(kt.a, xi), kt.b)
+}
+
+// publicShare maps the public share X_j of the threshold key to a*X_j + b*G
+func (kt *keyTweak) publicShare(Xj *crypto.ECPoint) (*crypto.ECPoint, error) {
+	aXj := Xj.ScalarMult(kt.a)
+	if kt.b.Sign() == 0 {
+		return aXj, nil
+	}
+	return aXj.Add(crypto.ScalarBaseMult(kt.pub.Curve(), kt.b))
+}
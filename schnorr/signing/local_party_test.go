@@ -0,0 +1,237 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2E(t *testing.T) {
+	setUp("info")
+
+	msg := sha256.Sum256([]byte("bip-340"))
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalParty(msg[:], params, key, out, end)
+	})
+
+	pubBz, err := XOnlyPublicKey(keys[0])
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, keys[0].ECDSAPub.X().FillBytes(make([]byte, XOnlyKeyLen)), pubBz, "the x-only key is the X of the threshold key")
+	pk, err := schnorr.ParsePubKey(pubBz)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, data := range signatures {
+		assert.Len(t, data.Signature, SignatureLen)
+		assert.Equal(t, signatures[0].Signature, data.Signature, "every party should output the same signature")
+		assert.Equal(t, pubBz, data.PublicKey)
+		assert.Equal(t, msg[:], data.M)
+		sig, err := schnorr.ParseSignature(data.Signature)
+		if assert.NoError(t, err) {
+			assert.True(t, sig.Verify(msg[:], pk), "the signature should verify with BIP-340")
+		}
+	}
+}
+
+func TestE2EWithTaprootTweak(t *testing.T) {
+	setUp("info")
+
+	msg := sha256.Sum256([]byte("taproot key path"))
+	for _, merkleRoot := range [][]byte{nil, common.SHA512_256([]byte("script tree"))} {
+		signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalPartyWithTaprootTweak(msg[:], merkleRoot, params, key, out, end)
+		})
+
+		// BIP-341: Q = lift_x(P.x) + H_TapTweak(P.x || merkle root) * G
+		internal := keys[0].ECDSAPub
+		if hasOddY(internal) {
+			internal = negatePoint(internal)
+		}
+		tweak := new(big.Int).SetBytes(taggedHash([]byte("TapTweak"), xOnlyBytes(internal), merkleRoot))
+		Q, err := internal.Add(crypto.ScalarBaseMult(tss.S256(), tweak))
+		if !assert.NoError(t, err) {
+			return
+		}
+		outputKey, err := TaprootOutputKey(keys[0], merkleRoot)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, xOnlyBytes(Q), outputKey)
+
+		pk, err := schnorr.ParsePubKey(outputKey)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, data := range signatures {
+			assert.Equal(t, outputKey, data.PublicKey)
+			sig, err := schnorr.ParseSignature(data.Signature)
+			if assert.NoError(t, err) {
+				assert.True(t, sig.Verify(msg[:], pk), "the signature should verify against the output key")
+			}
+		}
+	}
+}
+
+func TestTaprootOutputKeyErrors(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	_, err = TaprootOutputKey(keys[0], []byte{0x01, 0x02})
+	assert.Error(t, err, "the merkle root must be 32 bytes")
+
+	edKey := keys[0]
+	edKey.ECDSAPub = crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(7))
+	_, err = XOnlyPublicKey(edKey)
+	assert.Error(t, err, "the key must be on secp256k1")
+}
+
+func TestE2EPartialSignatureCulprit(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	cheater := 1
+	msg := sha256.Sum256([]byte("partial"))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(msg[:], params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	for len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			// the cheater's w_i is wrong, so it broadcasts a z_i that does not match its commitments and X_i
+			if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound1Message); ok && msg.GetFrom().Index == cheater {
+				P := parties[cheater].(*LocalParty)
+				P.temp.wi = new(big.Int).Add(P.temp.wi, big.NewInt(1))
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "signing should not succeed")
+		}
+	}
+
+	for _, err := range errs {
+		assert.Equal(t, 3, err.Round(), err.Error())
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+		}
+	}
+}
+
+func signWithFixtures(
+	t *testing.T,
+	newParty func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party,
+) ([]*common.SignatureData, []keygen.LocalPartySaveData) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	return signatures, keys
+}
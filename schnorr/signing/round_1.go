@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of FROST signing, the commitment to the nonces, see RFC 9591 section 5.1
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. select the hiding and binding nonces di, ei
+	N := round.Params().EC().Params().N
+	di := common.GetRandomPositiveInt(round.Rand(), N)
+	ei := common.GetRandomPositiveInt(round.Rand(), N)
+
+	// 2. commit to them
+	bigDi := crypto.ScalarBaseMult(round.Params().EC(), di)
+	bigEi := crypto.ScalarBaseMult(round.Params().EC(), ei)
+
+	// 3. store r1 message pieces
+	round.temp.di = di
+	round.temp.ei = ei
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast the commitments
+	r1msg := NewSignRound1Message(round.PartyID(), bigDi, bigEi)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning() with the share of the key that is signed for
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+	ks := round.key.Ks
+
+	if len(round.temp.m) != MessageLen {
+		return fmt.Errorf("BIP-340 signs %d-byte messages, got %d bytes", MessageLen, len(round.temp.m))
+	}
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	tweak, err := newKeyTweak(round.key.ECDSAPub, round.temp.taproot, round.temp.merkleRoot)
+	if err != nil {
+		return err
+	}
+
+	// the shares of the tweaked key, and their public counterparts, are affine in the shares of the threshold key
+	round.temp.bigXj = make([]*crypto.ECPoint, len(round.key.BigXj))
+	for j, Xj := range round.key.BigXj {
+		if round.temp.bigXj[j], err = tweak.publicShare(Xj); err != nil {
+			return fmt.Errorf("the public key share %d is invalid for the tweaked key: %v", j, err)
+		}
+	}
+	round.temp.wi = PrepareForSigning(round.Params().EC(), i, len(ks), tweak.share(round.key.Xi), ks)
+	round.temp.tweak = tweak
+	round.data.PublicKey = xOnlyBytes(tweak.pub)
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var (
+	tagChallenge = []byte("BIP0340/challenge")
+	tagTapTweak  = []byte("TapTweak")
+
+	// the FROST hashes H1, H4 and H5, as BIP-340 style tagged hashes
+	tagRho        = []byte("FROST/secp256k1/rho")
+	tagMessage    = []byte("FROST/secp256k1/msg")
+	tagCommitment = []byte("FROST/secp256k1/com")
+)
+
+// taggedHash is the tagged hash of BIP-340, SHA256(SHA256(tag) || SHA256(tag) || msgs...)
+func taggedHash(tag []byte, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256(tag)
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+	return h.Sum(nil)
+}
+
+// bindingFactors returns the binding factor rho_j of each signer, which ties its nonces to the message, to the key and
+// to the nonce commitments of every signer, see RFC 9591 section 4.4
+func bindingFactors(pub *crypto.ECPoint, msg []byte, ks []*big.Int, hiding, binding []*crypto.ECPoint) []*big.Int {
+	N := pub.Curve().Params().N
+	commitmentList := make([]byte, 0, len(ks)*(32+33+33))
+	for j, kj := range ks {
+		commitmentList = append(commitmentList, scalarBytes(kj)...)
+		commitmentList = append(commitmentList, compressedBytes(hiding[j])...)
+		commitmentList = append(commitmentList, compressedBytes(binding[j])...)
+	}
+	prefix := append(xOnlyBytes(pub), taggedHash(tagMessage, msg)...)
+	prefix = append(prefix, taggedHash(tagCommitment, commitmentList)...)
+
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		rho := new(big.Int).SetBytes(taggedHash(tagRho, prefix, scalarBytes(kj)))
+		rhos[j] = rho.Mod(rho, N)
+	}
+	return rhos
+}
+
+// challenge returns the BIP-340 challenge H_challenge(R.x || P.x || m) mod N
+func challenge(R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	c := new(big.Int).SetBytes(taggedHash(tagChallenge, xOnlyBytes(R), xOnlyBytes(pub), msg))
+	return c.Mod(c, pub.Curve().Params().N)
+}
+
+func scalarBytes(k *big.Int) []byte {
+	return k.FillBytes(make([]byte, 32))
+}
+
+func xOnlyBytes(p *crypto.ECPoint) []byte {
+	return p.X().FillBytes(make([]byte, XOnlyKeyLen))
+}
+
+func compressedBytes(p *crypto.ECPoint) []byte {
+	bz := make([]byte, 33)
+	bz[0] = 0x02 | byte(p.Y().Bit(0))
+	p.X().FillBytes(bz[1:])
+	return bz
+}
+
+func hasOddY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 1
+}
+
+func negatePoint(p *crypto.ECPoint) *crypto.ECPoint {
+	P := p.Curve().Params().P
+	return crypto.NewECPointNoCurveCheck(p.Curve(), p.X(), new(big.Int).Sub(P, p.Y()))
+}
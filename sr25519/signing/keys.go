@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// PublicKeyLen is the byte length of an sr25519 public key, a ristretto255 element
+	PublicKeyLen = ristretto255.EncodedLen
+	// SignatureLen is the byte length of an sr25519 signature, R || s with the Schnorrkel marker bit set in s
+	SignatureLen = 64
+
+	// SubstrateContext is the signing context of Substrate and Polkadot account signatures
+	SubstrateContext = "substrate"
+
+	// schnorrkel marks its signatures by setting the high bit of s, which is otherwise always clear
+	signatureMarker = 0x80
+)
+
+// PublicKey returns the sr25519 public key of the threshold key in `key`, the save data of EdDSA keygen. ristretto255
+// has the group order and the generator of Ed25519, so the EdDSA key x*G is also the sr25519 key of x; only the
+// encoding of the point differs. The signatures of NewLocalParty verify against it.
+func PublicKey(key keygen.LocalPartySaveData) ([]byte, error) {
+	if key.EDDSAPub == nil || !tss.SameCurve(key.EDDSAPub.Curve(), tss.Edwards()) {
+		return nil, errors.New("sr25519 signatures need an Ed25519 key")
+	}
+	return ristretto255.Encode(key.EDDSAPub), nil
+}
+
+// Verify returns an error unless `sig` is a valid Schnorrkel signature of `msg` in the signing context `context` by the
+// sr25519 public key `pub`, as schnorrkel's PublicKey.verify_simple checks it: s*B - k*A must encode to R.
+func Verify(pub, context, msg, sig []byte) error {
+	if len(sig) != SignatureLen {
+		return fmt.Errorf("the signature must be %d bytes", SignatureLen)
+	}
+	if sig[SignatureLen-1]&signatureMarker == 0 {
+		return errors.New("the signature is not marked as a Schnorrkel signature")
+	}
+	A, err := ristretto255.Decode(pub)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %v", err)
+	}
+	sBytes := append([]byte{}, sig[32:]...)
+	sBytes[31] &^= signatureMarker
+	s := new(big.Int).SetBytes(reverseBytes(sBytes))
+	N := tss.Edwards().Params().N
+	if s.Cmp(N) >= 0 {
+		return errors.New("the signature s is not reduced")
+	}
+
+	k := challenge(context, msg, pub, sig[:32])
+	negK := new(big.Int).Mod(new(big.Int).Sub(N, k), N)
+	R, err := crypto.ScalarBaseMult(tss.Edwards(), s).Add(A.ScalarMult(negK))
+	if err != nil || string(ristretto255.Encode(R)) != string(sig[:32]) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// ----- //
+
+// signatureBytes returns the Schnorrkel encoding of the signature (R, s)
+func signatureBytes(R *crypto.ECPoint, s *big.Int) []byte {
+	sig := append(ristretto255.Encode(R), scalarBytes(s)...)
+	sig[SignatureLen-1] |= signatureMarker
+	return sig
+}
@@ -0,0 +1,245 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2E(t *testing.T) {
+	setUp("info")
+
+	msg := []byte("sr25519 transfer")
+	context := []byte(SubstrateContext)
+	signatures, keys := signWithFixtures(t, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalParty(msg, context, params, key, out, end)
+	})
+
+	pub, err := PublicKey(keys[0])
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, pub, PublicKeyLen)
+	A, err := ristretto255.Decode(pub)
+	if assert.NoError(t, err) {
+		assert.True(t, ristretto255.Equal(A, keys[0].EDDSAPub), "the public key is the ristretto255 encoding of the threshold key")
+	}
+	for _, data := range signatures {
+		assert.Len(t, data.Signature, SignatureLen)
+		assert.Equal(t, signatures[0].Signature, data.Signature, "every party should output the same signature")
+		assert.Equal(t, pub, data.PublicKey)
+		assert.Equal(t, msg, data.M)
+		assert.NoError(t, Verify(pub, context, msg, data.Signature), "the signature should verify with sr25519")
+	}
+
+	sig := signatures[0].Signature
+	assert.Error(t, Verify(pub, []byte("other context"), msg, sig), "the context is signed")
+	assert.Error(t, Verify(pub, context, []byte("other message"), sig), "the message is signed")
+	other := crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(7))
+	assert.Error(t, Verify(ristretto255.Encode(other), context, msg, sig), "the key is signed")
+}
+
+func TestVerifyErrors(t *testing.T) {
+	ec := tss.Edwards()
+	x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	pub := ristretto255.Encode(crypto.ScalarBaseMult(ec, x))
+	context, msg := []byte(SubstrateContext), []byte("single signer")
+
+	// the signature of schnorrkel's SecretKey.sign, with the nonce r
+	r := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	R := crypto.ScalarBaseMult(ec, r)
+	k := challenge(context, msg, pub, ristretto255.Encode(R))
+	s := common.ModInt(ec.Params().N).Add(r, new(big.Int).Mul(k, x))
+	sig := signatureBytes(R, s)
+	if !assert.NoError(t, Verify(pub, context, msg, sig)) {
+		return
+	}
+
+	assert.Error(t, Verify(pub, context, msg, sig[:63]), "the signature must be 64 bytes")
+	unmarked := append([]byte{}, sig...)
+	unmarked[63] &^= signatureMarker
+	assert.Error(t, Verify(pub, context, msg, unmarked), "the signature must carry the marker bit")
+	unreduced := append([]byte{}, sig...)
+	unreducedS := new(big.Int).Add(s, ec.Params().N)
+	copy(unreduced[32:], reverseBytes(unreducedS.FillBytes(make([]byte, 32))))
+	unreduced[63] |= signatureMarker
+	assert.Error(t, Verify(pub, context, msg, unreduced), "s must be reduced")
+	badPub := append([]byte{}, pub...)
+	badPub[0] |= 1
+	assert.Error(t, Verify(badPub, context, msg, sig), "the public key must be a canonical encoding")
+}
+
+// TestVerifyKnownAnswer verifies a signature made by the Rust schnorrkel, from the tests of sr25519-crust
+// (test/ds.cpp), so that Verify and the challenge agree with schnorrkel and not just with this package's signing.
+func TestVerifyKnownAnswer(t *testing.T) {
+	pub, _ := hex.DecodeString("46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a")
+	sig, _ := hex.DecodeString("4e172314444b8f820bb54c22e95076f220ed25373e5c178234aa6c211d29271244b947e3ff3418ff6b45fd1df1140c8cbff69fc58ee6dc96df70936a2bb74b82")
+	context, msg := []byte(SubstrateContext), []byte("this is a message")
+	assert.NoError(t, Verify(pub, context, msg, sig))
+
+	assert.Error(t, Verify(pub, context, []byte("this is another message"), sig), "the message is signed")
+	assert.Error(t, Verify(pub, []byte("another context"), msg, sig), "the signing context is signed")
+}
+
+func TestPublicKeyErrors(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	key := keys[0]
+	key.EDDSAPub = crypto.ScalarBaseMult(tss.S256(), big.NewInt(7))
+	_, err = PublicKey(key)
+	assert.Error(t, err, "the key must be on Ed25519")
+}
+
+func TestE2EPartialSignatureCulprit(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	cheater := 1
+	msg := []byte("partial")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(msg, []byte(SubstrateContext), params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make(map[int]*tss.Error, len(signPIDs))
+	for len(errs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+
+		case msg := <-outCh:
+			// the cheater's w_i is wrong, so it broadcasts a z_i that does not match its commitments and X_i
+			if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound1Message); ok && msg.GetFrom().Index == cheater {
+				P := parties[cheater].(*LocalParty)
+				P.temp.wi = new(big.Int).Add(P.temp.wi, big.NewInt(1))
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "signing should not succeed")
+		}
+	}
+
+	for _, err := range errs {
+		assert.Equal(t, 3, err.Round(), err.Error())
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index, "the cheater should be identified")
+		}
+	}
+}
+
+func signWithFixtures(
+	t *testing.T,
+	newParty func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party,
+) ([]*common.SignatureData, []keygen.LocalPartySaveData) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	threshold := len(fixtures[0].Ks) / 2
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(threshold+1, len(fixtures[0].Ks))
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		}
+	}
+	return signatures, keys
+}
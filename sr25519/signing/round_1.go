@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of FROST signing, the commitment to the nonces, see RFC 9591 section 5.1
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 1. generate the hiding and binding nonces di, ei
+	di, err := nonceGenerate(round.Rand(), round.key.Xi)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	ei, err := nonceGenerate(round.Rand(), round.key.Xi)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// 2. commit to them
+	bigDi := crypto.ScalarBaseMult(round.Params().EC(), di)
+	bigEi := crypto.ScalarBaseMult(round.Params().EC(), ei)
+
+	// 3. store r1 message pieces
+	round.temp.di = di
+	round.temp.ei = ei
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast the commitments
+	r1msg := NewSignRound1Message(round.PartyID(), bigDi, bigEi)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+	ks := round.key.Ks
+
+	if !tss.SameCurve(round.Params().EC(), tss.Edwards()) {
+		return errors.New("sr25519 signing runs on the Ed25519 curve, tss.Edwards()")
+	}
+	pub, err := PublicKey(*round.key)
+	if err != nil {
+		return err
+	}
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	round.temp.wi = PrepareForSigning(round.Params().EC(), i, len(ks), round.key.Xi, ks)
+	round.data.PublicKey = pub
+	return nil
+}
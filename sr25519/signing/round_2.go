@@ -0,0 +1,107 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 2 computes the group commitment R and the signature share z_i, see RFC 9591 section 5.2. The challenge is the
+// one of Schnorrkel rather than H2, so that the signature is an sr25519 signature.
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	i := round.PartyID().Index
+
+	// 1. store r1 message pieces
+	for j, msg := range round.temp.signRound1Messages {
+		r1msg := msg.Content().(*SignRound1Message)
+		Dj, Ej, err := r1msg.UnmarshalCommitments()
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "UnmarshalCommitments(Dj, Ej)"), round.Parties().IDs()[j])
+		}
+		round.temp.bigDjs[j], round.temp.bigEjs[j] = Dj, Ej
+	}
+
+	// 2. compute the binding factors and R = sum(D_j + rho_j * E_j)
+	pub := round.key.EDDSAPub
+	round.temp.rhos = bindingFactors(pub, round.temp.m, round.key.Ks, round.temp.bigDjs, round.temp.bigEjs)
+	R, err := round.nonceCommitment(0)
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "nonceCommitment(0)"), round.Parties().IDs()[0])
+	}
+	for j := 1; j < len(round.temp.bigDjs); j++ {
+		Rj, err := round.nonceCommitment(j)
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "nonceCommitment(j)"), round.Parties().IDs()[j])
+		}
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "the group commitment R is invalid"))
+		}
+	}
+	round.temp.bigR = R
+	round.temp.c = challenge(round.temp.context, round.temp.m, round.data.PublicKey, ristretto255.Encode(R))
+
+	// 3. compute z_i = d_i + rho_i * e_i + lambda_i * c * x_i, in which w_i = lambda_i * x_i
+	zi := modN.Add(round.temp.di, modN.Mul(round.temp.rhos[i], round.temp.ei))
+	zi = modN.Add(zi, modN.Mul(round.temp.c, round.temp.wi))
+	round.temp.zi = zi
+
+	// 4. broadcast z_i to other parties
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	round.out <- r2msg
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// nonceCommitment returns the commitment D_j + rho_j * E_j of party j to its nonce
+func (round *round2) nonceCommitment(j int) (*crypto.ECPoint, error) {
+	return round.temp.bigDjs[j].Add(round.temp.bigEjs[j].ScalarMult(round.temp.rhos[j]))
+}
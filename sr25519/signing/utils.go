@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha512"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/gtank/merlin"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ristretto255"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// contextString is the domain separation of the FROST(ristretto255, SHA-512) ciphersuite, see RFC 9591 section 6.2
+const contextString = "FROST-RISTRETTO255-SHA512-v1"
+
+const scalarLen = 32
+
+// nonceGenerate is nonce_generate of RFC 9591 section 4.1: H3(random_bytes(32) || SerializeScalar(secret)). Hashing
+// in the key share protects the nonce against a weak source of randomness.
+func nonceGenerate(rand io.Reader, secret *big.Int) (*big.Int, error) {
+	randomBytes, err := common.GetRandomBytes(rand, 32)
+	if err != nil {
+		return nil, err
+	}
+	return hashToScalar([]byte(contextString+"nonce"), randomBytes, scalarBytes(secret)), nil
+}
+
+// bindingFactors returns the binding factor rho_j of each signer, which ties its nonces to the message, to the key and
+// to the nonce commitments of every signer, see RFC 9591 section 4.4. The identifier of a signer is its share index.
+func bindingFactors(pub *crypto.ECPoint, msg []byte, ks []*big.Int, hiding, binding []*crypto.ECPoint) []*big.Int {
+	// the commitment list is sorted by identifier, whatever the order of the signers' party IDs
+	order := make([]int, len(ks))
+	for j := range order {
+		order[j] = j
+	}
+	sort.Slice(order, func(a, b int) bool { return ks[order[a]].Cmp(ks[order[b]]) < 0 })
+	commitmentList := make([]byte, 0, len(ks)*(scalarLen+2*ristretto255.EncodedLen))
+	for _, j := range order {
+		commitmentList = append(commitmentList, scalarBytes(ks[j])...)
+		commitmentList = append(commitmentList, ristretto255.Encode(hiding[j])...)
+		commitmentList = append(commitmentList, ristretto255.Encode(binding[j])...)
+	}
+
+	msgHash := sha512.Sum512(append([]byte(contextString+"msg"), msg...))
+	commitmentHash := sha512.Sum512(append([]byte(contextString+"com"), commitmentList...))
+	prefix := append(ristretto255.Encode(pub), msgHash[:]...)
+	prefix = append(prefix, commitmentHash[:]...)
+
+	rhos := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		rhos[j] = hashToScalar([]byte(contextString+"rho"), prefix, scalarBytes(kj))
+	}
+	return rhos
+}
+
+// challenge returns the Schnorrkel challenge of a signature of `msg` in the signing context `context`, derived from
+// the Merlin transcript of schnorrkel's sign and verify with the encodings `pubBz` of the public key and `RBz` of R
+func challenge(context, msg, pubBz, RBz []byte) *big.Int {
+	t := merlin.NewTranscript("SigningContext")
+	t.AppendMessage([]byte(""), context)
+	t.AppendMessage([]byte("sign-bytes"), msg)
+	t.AppendMessage([]byte("proto-name"), []byte("Schnorr-sig"))
+	t.AppendMessage([]byte("sign:pk"), pubBz)
+	t.AppendMessage([]byte("sign:R"), RBz)
+	return wideScalar(t.ExtractBytes([]byte("sign:c"), 64))
+}
+
+// hashToScalar reduces the SHA-512 of its inputs, read little endian, modulo the group order
+func hashToScalar(in ...[]byte) *big.Int {
+	h := sha512.New()
+	for _, bz := range in {
+		h.Write(bz)
+	}
+	return wideScalar(h.Sum(nil))
+}
+
+// wideScalar reduces the 64 bytes `bz`, read little endian, modulo the group order
+func wideScalar(bz []byte) *big.Int {
+	s := new(big.Int).SetBytes(reverseBytes(bz))
+	return s.Mod(s, tss.Edwards().Params().N)
+}
+
+// scalarBytes is SerializeScalar, the 32-byte little endian encoding of a scalar
+func scalarBytes(k *big.Int) []byte {
+	return reverseBytes(new(big.Int).Mod(k, tss.Edwards().Params().N).FillBytes(make([]byte, scalarLen)))
+}
+
+// reverseBytes returns a reversed copy of `bz`
+func reverseBytes(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i := range bz {
+		out[len(bz)-1-i] = bz[i]
+	}
+	return out
+}
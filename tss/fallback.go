@@ -0,0 +1,158 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// ErrRoundTimeout is the cause of the *Error sent by WatchRounds when a party has waited too long for the messages of
+// a round. The culprits of the error are the parties that did not send them.
+var ErrRoundTimeout = errors.New("timed out waiting for the messages of a round")
+
+// SignSessionFunc runs one signing session between `signers`, t+1 of the key holders, and returns the signature. A
+// session that stalls should return the error sent by WatchRounds, so that the silent signers can be replaced.
+type SignSessionFunc func(signers SortedPartyIDs) (*common.SignatureData, *Error)
+
+// WatchRounds reports through the returned channel when `p` has been waiting for the same parties in a round for
+// longer than `timeout`, with those parties as the culprits of an ErrRoundTimeout. A party whose message has been
+// stored is never reported, even if the round has not processed it yet; an error found while processing it is sent
+// instead. It reports at most once and stops watching when `done` is closed.
+func WatchRounds(p Party, timeout time.Duration, done <-chan struct{}) <-chan *Error {
+	errCh := make(chan *Error, 1)
+	interval := timeout / 10
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		lastRound, lastWaiting, since := -1, "", time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			number, waiting, timeoutErr := awaitedParties(p)
+			if timeoutErr != nil && !errors.Is(timeoutErr, ErrRoundTimeout) {
+				// a message that had not been processed yet was rejected
+				errCh <- timeoutErr
+				return
+			}
+			if timeoutErr == nil {
+				// not started, finished or processing a round
+				lastRound, lastWaiting, since = -1, "", time.Now()
+				continue
+			}
+			if key := partyKeys(waiting); number != lastRound || key != lastWaiting {
+				lastRound, lastWaiting, since = number, key, time.Now()
+				continue
+			}
+			if time.Since(since) >= timeout {
+				errCh <- timeoutErr
+				return
+			}
+		}
+	}()
+	return errCh
+}
+
+// SignWithFallback signs with the threshold key held by `holders`, restarting with another subset when signers do not
+// respond. Each session is run by `sign` with the first t+1 holders that have not been excluded; the signers are copies
+// of the holders' PartyIDs, indexed within the session. When a session fails with an ErrRoundTimeout, its culprits
+// are excluded and the next session replaces them. Any other error ends signing. The excluded holders are returned
+// along with the signature, or the error once fewer than t+1 holders remain.
+func SignWithFallback(holders SortedPartyIDs, threshold int, sign SignSessionFunc) (*common.SignatureData, []*PartyID, error) {
+	if threshold < 0 || len(holders) <= threshold {
+		return nil, nil, fmt.Errorf("SignWithFallback: %d holders cannot sign with threshold %d", len(holders), threshold)
+	}
+	excluded := make([]*PartyID, 0, len(holders)-threshold-1)
+	isExcluded := make(map[string]bool, len(holders))
+	for {
+		signers := make(UnSortedPartyIDs, 0, threshold+1)
+		for _, holder := range holders {
+			if len(signers) == threshold+1 {
+				break
+			}
+			if !isExcluded[holder.KeyInt().String()] {
+				signers = append(signers, NewPartyID(holder.Id, holder.Moniker, holder.KeyInt()))
+			}
+		}
+		if len(signers) <= threshold {
+			return nil, excluded, fmt.Errorf(
+				"SignWithFallback: %d holders did not respond, leaving too few to sign with threshold %d", len(excluded), threshold)
+		}
+
+		sigData, err := sign(SortPartyIDs(signers))
+		if err == nil {
+			return sigData, excluded, nil
+		}
+		if !errors.Is(err, ErrRoundTimeout) || len(err.Culprits()) == 0 {
+			return nil, excluded, err
+		}
+		for _, culprit := range err.Culprits() {
+			holder := holders.FindByKey(culprit.KeyInt())
+			if holder == nil {
+				return nil, excluded, fmt.Errorf("SignWithFallback: the unresponsive party %s is not a holder: %w", culprit, err)
+			}
+			if key := holder.KeyInt().String(); !isExcluded[key] {
+				isExcluded[key] = true
+				excluded = append(excluded, holder)
+			}
+		}
+		common.Logger.Warnf("SignWithFallback: restarting signing without the unresponsive parties %s", err.Culprits())
+	}
+}
+
+// ----- //
+
+// awaitedParties returns the number of the current round of `p`, the other parties that it is waiting for and the
+// error to report if they time out; the error is nil when `p` is not waiting for anyone. The round is updated first,
+// as a message stored before the round started or since its last update is not yet marked as received.
+func awaitedParties(p Party) (int, []*PartyID, *Error) {
+	p.lock()
+	defer p.unlock()
+	rnd := p.round()
+	if rnd == nil {
+		return -1, nil, nil
+	}
+	if _, err := rnd.Update(); err != nil {
+		return rnd.RoundNumber(), nil, err
+	}
+	if rnd.CanProceed() {
+		// every awaited message has been stored
+		return rnd.RoundNumber(), nil, nil
+	}
+	self := p.PartyID().KeyInt()
+	waiting := make([]*PartyID, 0, len(rnd.WaitingFor()))
+	for _, Pj := range rnd.WaitingFor() {
+		// a party only marks its own message as received once it processes another party's message
+		if Pj.KeyInt().Cmp(self) != 0 {
+			waiting = append(waiting, Pj)
+		}
+	}
+	if len(waiting) == 0 {
+		return rnd.RoundNumber(), nil, nil
+	}
+	return rnd.RoundNumber(), waiting, rnd.WrapError(ErrRoundTimeout, waiting...)
+}
+
+func partyKeys(ids []*PartyID) string {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.KeyInt().String()
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
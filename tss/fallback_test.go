@@ -0,0 +1,154 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWatchTimeout = 100 * time.Millisecond
+
+type (
+	// watchedParty is a party stuck in watchedRound, for testing WatchRounds
+	watchedParty struct {
+		*BaseParty
+		params *Parameters
+	}
+
+	// watchedRound marks the messages in `stored` as received only when it is updated, like the protocol rounds
+	watchedRound struct {
+		params    *Parameters
+		stored    []bool
+		ok        []bool
+		updateErr error
+	}
+)
+
+func newWatchedParty(stored []bool, updateErr error) (*watchedParty, *watchedRound) {
+	pIDs := GenerateTestPartyIDs(len(stored))
+	params := NewParameters(S256(), NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+	rnd := &watchedRound{params: params, stored: stored, ok: make([]bool, len(stored)), updateErr: updateErr}
+	return &watchedParty{BaseParty: &BaseParty{rnd: rnd}, params: params}, rnd
+}
+
+func (p *watchedParty) Start() *Error { return nil }
+func (p *watchedParty) UpdateFromBytes([]byte, *PartyID, bool) (bool, *Error) {
+	return false, nil
+}
+func (p *watchedParty) Update(ParsedMessage) (bool, *Error)          { return false, nil }
+func (p *watchedParty) ValidateMessage(ParsedMessage) (bool, *Error) { return true, nil }
+func (p *watchedParty) StoreMessage(ParsedMessage) (bool, *Error)    { return true, nil }
+func (p *watchedParty) FirstRound() Round                            { return p.rnd }
+func (p *watchedParty) PartyID() *PartyID                            { return p.params.PartyID() }
+func (p *watchedParty) String() string                               { return p.PartyID().String() }
+func (p *watchedParty) setRound(round Round) *Error {
+	p.rnd = round
+	return nil
+}
+
+func (round *watchedRound) Params() *Parameters          { return round.params }
+func (round *watchedRound) Start() *Error                { return nil }
+func (round *watchedRound) RoundNumber() int             { return 1 }
+func (round *watchedRound) CanAccept(ParsedMessage) bool { return true }
+func (round *watchedRound) NextRound() Round             { return nil }
+
+func (round *watchedRound) Update() (bool, *Error) {
+	if round.updateErr != nil {
+		return false, round.WrapError(round.updateErr, round.params.Parties().IDs()[1])
+	}
+	copy(round.ok, round.stored)
+	return round.CanProceed(), nil
+}
+
+func (round *watchedRound) CanProceed() bool {
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (round *watchedRound) WaitingFor() []*PartyID {
+	ids := make([]*PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if !ok {
+			ids = append(ids, round.params.Parties().IDs()[j])
+		}
+	}
+	return ids
+}
+
+func (round *watchedRound) WrapError(err error, culprits ...*PartyID) *Error {
+	return NewError(err, "watch", round.RoundNumber(), round.params.PartyID(), culprits...)
+}
+
+func TestWatchRoundsReportsSilentParties(t *testing.T) {
+	// the party's own message and the second party's are stored; the other two never arrive
+	p, _ := newWatchedParty([]bool{true, true, false, false}, nil)
+	done := make(chan struct{})
+	defer close(done)
+
+	select {
+	case err := <-WatchRounds(p, testWatchTimeout, done):
+		if assert.True(t, errors.Is(err, ErrRoundTimeout)) {
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*PartyID(p.params.Parties().IDs()[2:]), err.Culprits())
+		}
+	case <-time.After(20 * testWatchTimeout):
+		assert.FailNow(t, "the silent parties should be reported")
+	}
+}
+
+func TestWatchRoundsIgnoresStoredMessages(t *testing.T) {
+	// the other parties' messages are stored but not processed by the round, e.g. because they arrived before Start;
+	// the party's own message is only marked once it processes them
+	p, rnd := newWatchedParty([]bool{false, true, true, true}, nil)
+	done := make(chan struct{})
+	defer close(done)
+
+	select {
+	case err := <-WatchRounds(p, testWatchTimeout, done):
+		assert.FailNow(t, "no party should be reported", err)
+	case <-time.After(5 * testWatchTimeout):
+	}
+	p.lock()
+	defer p.unlock()
+	assert.Equal(t, []*PartyID{p.PartyID()}, rnd.WaitingFor(), "the watcher should have updated the round")
+}
+
+func TestWatchRoundsReportsUpdateError(t *testing.T) {
+	p, _ := newWatchedParty([]bool{true, true, true}, errors.New("bad message"))
+	done := make(chan struct{})
+	defer close(done)
+
+	select {
+	case err := <-WatchRounds(p, testWatchTimeout, done):
+		assert.False(t, errors.Is(err, ErrRoundTimeout), "the rejected message is not a timeout")
+		assert.Equal(t, "bad message", err.Cause().Error())
+		assert.Equal(t, []*PartyID(p.params.Parties().IDs()[1:2]), err.Culprits())
+	case <-time.After(20 * testWatchTimeout):
+		assert.FailNow(t, "the update error should be reported")
+	}
+}
+
+func TestWatchRoundsStopsWhenDone(t *testing.T) {
+	p, _ := newWatchedParty([]bool{true, false, false}, nil)
+	done := make(chan struct{})
+	errCh := WatchRounds(p, testWatchTimeout, done)
+	close(done)
+
+	select {
+	case err := <-errCh:
+		assert.FailNow(t, "nothing should be reported once done is closed", err)
+	case <-time.After(5 * testWatchTimeout):
+	}
+}
@@ -9,7 +9,10 @@ package tss
 import (
 	"crypto/elliptic"
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"io"
+	"math/big"
 	"runtime"
 	"time"
 )
@@ -26,22 +29,45 @@ type (
 		// proof session info
 		nonce int
 		// for keygen
-		noProofMod bool
-		noProofFac bool
+		noProofMod         bool
+		noProofFac         bool
+		paillierModulusLen int
+		// for ecdsa signing
+		lowS LowSMode
+		// x-coordinates of the shares, aligned with parties.IDs(); the parties' keys if nil
+		shareIndexes []*big.Int
 		// random sources
 		partialKeyRand, rand io.Reader
 	}
 
+	// LowSMode is how ECDSA signing normalises the S of the signature to the lower half of the curve order
+	LowSMode int
+
 	ReSharingParameters struct {
 		*Parameters
 		newParties    *PeerContext
 		newPartyCount int
 		newThreshold  int
+		// x-coordinates of the new shares, aligned with newParties.IDs(); the new parties' keys if nil
+		newShareIndexes []*big.Int
 	}
 )
 
+const (
+	// LowSAlways replaces S with N-S when S > N/2. It is the default.
+	LowSAlways LowSMode = iota
+	// LowSNever outputs S as computed, for verifiers that expect the original S
+	LowSNever
+	// LowSAsRequiredByCurve normalises S on secp256k1, whose verifiers (bitcoin, ethereum, tendermint) reject a high S,
+	// and leaves it as computed on other curves
+	LowSAsRequiredByCurve
+)
+
 const (
 	defaultSafePrimeGenTimeout = 5 * time.Minute
+
+	// DefaultPaillierModulusLen is the bit length of the Paillier modulus and NTilde recommended in the GG18 spec
+	DefaultPaillierModulusLen = 2048
 )
 
 // Exported, used in `tss` client
@@ -54,6 +80,7 @@ func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyC
 		threshold:           threshold,
 		concurrency:         runtime.GOMAXPROCS(0),
 		safePrimeGenTimeout: defaultSafePrimeGenTimeout,
+		paillierModulusLen:  DefaultPaillierModulusLen,
 		partialKeyRand:      rand.Reader,
 		rand:                rand.Reader,
 	}
@@ -104,14 +131,92 @@ func (params *Parameters) NoProofFac() bool {
 	return params.noProofFac
 }
 
+// PaillierModulusLen is the bit length of the Paillier modulus and NTilde generated by this party,
+// and the minimum bit length accepted for the Paillier moduli and NTildes of the other parties.
+func (params *Parameters) PaillierModulusLen() int {
+	return params.paillierModulusLen
+}
+
+// SetPaillierModulusLen sets the security level of the Paillier modulus and NTilde, e.g. 2048, 3072 or 4096.
+// It returns an error, and keeps the previous length, unless the length is even and no less than
+// DefaultPaillierModulusLen.
+func (params *Parameters) SetPaillierModulusLen(bits int) error {
+	if bits < DefaultPaillierModulusLen || bits%2 != 0 {
+		return fmt.Errorf("the Paillier modulus length must be even and at least %d bits, got %d", DefaultPaillierModulusLen, bits)
+	}
+	params.paillierModulusLen = bits
+	return nil
+}
+
+// SetNoProofMod skips the Paillier modulus proof and accepts peers that skip it too. Do not use in an untrusted setting.
 func (params *Parameters) SetNoProofMod() {
 	params.noProofMod = true
 }
 
+// SetNoProofFac skips the Paillier factorization proof and accepts peers that skip it too. Do not use in an untrusted setting.
 func (params *Parameters) SetNoProofFac() {
 	params.noProofFac = true
 }
 
+// LowS returns how ECDSA signing normalises S, LowSAlways unless set otherwise
+func (params *Parameters) LowS() LowSMode {
+	return params.lowS
+}
+
+// SetLowS sets how ECDSA signing normalises S. The recovery ID of the signature is adjusted to match.
+func (params *Parameters) SetLowS(mode LowSMode) {
+	params.lowS = mode
+}
+
+// ShareIndexes returns the x-coordinates of the parties' shares, aligned with Parties().IDs().
+// These are the parties' keys unless explicit indexes were set with SetShareIndexes.
+func (params *Parameters) ShareIndexes() []*big.Int {
+	if params.shareIndexes != nil {
+		return params.shareIndexes
+	}
+	return params.parties.IDs().Keys()
+}
+
+// ShareIndex returns the x-coordinate of this party's share
+func (params *Parameters) ShareIndex() *big.Int {
+	return params.ShareIndexes()[params.partyID.Index]
+}
+
+// SetShareIndexes decouples the x-coordinates of the shares from the parties' keys, so that a party's key may change
+// without changing its share. `indexes[j]` is the share index of Parties().IDs()[j]. It returns an error, and keeps
+// the previous indexes, unless there is one index per party and the indexes are distinct and non-zero modulo the
+// curve order, see CheckShareIndexes.
+// The same indexes must be used for keygen and for signing and re-sharing with the resulting key; they are also saved
+// as the `Ks` in the save data.
+func (params *Parameters) SetShareIndexes(indexes []*big.Int) error {
+	if err := checkShareIndexesFor(params.ec, params.parties, indexes); err != nil {
+		return err
+	}
+	params.shareIndexes = indexes
+	return nil
+}
+
+// CheckShareIndexes returns an error if any of the x-coordinates `indexes` of Shamir shares is 0, or if two of them are
+// equal, modulo the order of `ec`
+func CheckShareIndexes(ec elliptic.Curve, indexes []*big.Int) error {
+	visited := make(map[string]struct{}, len(indexes))
+	for _, v := range indexes {
+		if v == nil {
+			return errors.New("party index should not be nil")
+		}
+		vMod := new(big.Int).Mod(v, ec.Params().N)
+		if vMod.Sign() == 0 {
+			return errors.New("party index should not be 0")
+		}
+		vModStr := vMod.String()
+		if _, ok := visited[vModStr]; ok {
+			return fmt.Errorf("duplicate indexes %s", vModStr)
+		}
+		visited[vModStr] = struct{}{}
+	}
+	return nil
+}
+
 func (params *Parameters) PartialKeyRand() io.Reader {
 	return params.partialKeyRand
 }
@@ -161,6 +266,30 @@ func (rgParams *ReSharingParameters) NewThreshold() int {
 	return rgParams.newThreshold
 }
 
+// NewShareIndexes returns the x-coordinates of the new committee's shares, aligned with NewParties().IDs().
+// These are the new parties' keys unless explicit indexes were set with SetNewShareIndexes.
+// The old committee's indexes are given by ShareIndexes().
+func (rgParams *ReSharingParameters) NewShareIndexes() []*big.Int {
+	if rgParams.newShareIndexes != nil {
+		return rgParams.newShareIndexes
+	}
+	return rgParams.newParties.IDs().Keys()
+}
+
+// NewShareIndex returns the x-coordinate of this party's share in the new committee
+func (rgParams *ReSharingParameters) NewShareIndex() *big.Int {
+	return rgParams.NewShareIndexes()[rgParams.partyID.Index]
+}
+
+// SetNewShareIndexes sets explicit share indexes for the new committee, see SetShareIndexes
+func (rgParams *ReSharingParameters) SetNewShareIndexes(indexes []*big.Int) error {
+	if err := checkShareIndexesFor(rgParams.EC(), rgParams.newParties, indexes); err != nil {
+		return err
+	}
+	rgParams.newShareIndexes = indexes
+	return nil
+}
+
 func (rgParams *ReSharingParameters) OldAndNewParties() []*PartyID {
 	return append(rgParams.OldParties().IDs(), rgParams.NewParties().IDs()...)
 }
@@ -188,3 +317,12 @@ func (rgParams *ReSharingParameters) IsNewCommittee() bool {
 	}
 	return false
 }
+
+// ----- //
+
+func checkShareIndexesFor(ec elliptic.Curve, parties *PeerContext, indexes []*big.Int) error {
+	if len(indexes) != len(parties.IDs()) {
+		return fmt.Errorf("expected %d share indexes, one per party, got %d", len(parties.IDs()), len(indexes))
+	}
+	return CheckShareIndexes(ec, indexes)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetShareIndexes(t *testing.T) {
+	pIDs := GenerateTestPartyIDs(3)
+	params := NewParameters(S256(), NewPeerContext(pIDs), pIDs[1], len(pIDs), 1)
+	assert.Equal(t, pIDs.Keys(), params.ShareIndexes())
+
+	N := S256().Params().N
+	for name, indexes := range map[string][]*big.Int{
+		"too few":    {big.NewInt(1), big.NewInt(2)},
+		"too many":   {big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)},
+		"zero":       {big.NewInt(1), big.NewInt(0), big.NewInt(3)},
+		"zero mod N": {big.NewInt(1), new(big.Int).Set(N), big.NewInt(3)},
+		"duplicate":  {big.NewInt(1), big.NewInt(2), new(big.Int).Add(N, big.NewInt(2))},
+		"nil":        {big.NewInt(1), nil, big.NewInt(3)},
+	} {
+		assert.Error(t, params.SetShareIndexes(indexes), name)
+		assert.Equal(t, pIDs.Keys(), params.ShareIndexes(), "%s: rejected indexes must not be used", name)
+	}
+
+	indexes := []*big.Int{big.NewInt(7), big.NewInt(8), big.NewInt(9)}
+	assert.NoError(t, params.SetShareIndexes(indexes))
+	assert.Equal(t, indexes, params.ShareIndexes())
+	assert.Equal(t, indexes[1], params.ShareIndex())
+}
+
+func TestSetNewShareIndexes(t *testing.T) {
+	oldPIDs := GenerateTestPartyIDs(3)
+	newPIDs := GenerateTestPartyIDs(4)
+	params := NewReSharingParameters(S256(), NewPeerContext(oldPIDs), NewPeerContext(newPIDs), newPIDs[0], 3, 1, 4, 2)
+
+	assert.Error(t, params.SetNewShareIndexes([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}), "one index per new party")
+	assert.Error(t, params.SetNewShareIndexes([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(3)}))
+	indexes := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	assert.NoError(t, params.SetNewShareIndexes(indexes))
+	assert.Equal(t, indexes, params.NewShareIndexes())
+	assert.Equal(t, indexes[0], params.NewShareIndex())
+}
+
+func TestSetPaillierModulusLen(t *testing.T) {
+	pIDs := GenerateTestPartyIDs(3)
+	params := NewParameters(S256(), NewPeerContext(pIDs), pIDs[1], len(pIDs), 1)
+	assert.Equal(t, DefaultPaillierModulusLen, params.PaillierModulusLen())
+
+	for _, bits := range []int{0, 1024, DefaultPaillierModulusLen - 2, 3071} {
+		assert.Error(t, params.SetPaillierModulusLen(bits), "%d bits", bits)
+		assert.Equal(t, DefaultPaillierModulusLen, params.PaillierModulusLen(), "%d bits must not be used", bits)
+	}
+
+	assert.NoError(t, params.SetPaillierModulusLen(3072))
+	assert.Equal(t, 3072, params.PaillierModulusLen())
+}